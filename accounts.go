@@ -2,10 +2,14 @@ package masta
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -32,6 +36,7 @@ type Account struct {
 	Bot            bool           `json:"bot"`
 	Discoverable   bool           `json:"discoverable"`
 	Source         *AccountSource `json:"source"`
+	MuteExpiresAt  time.Time      `json:"mute_expires_at"`
 	// TODO: Split off some of these fields into their own objects,
 	// or do something to fix the Pleroma API's dumpster fire of redundant
 	// data.
@@ -40,6 +45,7 @@ type Account struct {
 		AlsoKnownAs         []string                `json:"also_known_as"`
 		AllowFollowingMove  bool                    `json:"allow_following_move"`
 		ApID                string                  `json:"ap_id"`
+		Birthday            *string                 `json:"birthday"`
 		ChatToken           *string                 `json:"chat_token"`
 		BackgroundImage     *string                 `json:"background_image"`
 		Deactivated         bool                    `json:"deactivated"`
@@ -53,11 +59,24 @@ type Account struct {
 		IsConfirmed         *bool                   `json:"is_confirmed"`
 		IsModerator         *bool                   `json:"is_moderator"`
 		IsSuggested         *bool                   `json:"is_suggested"`
+		MuteExpiresAt       *time.Time              `json:"mute_expires_at"`
 		Relationship        *Relationship           `json:"relationship"`
 		SettingsStore       *map[string]interface{} `json:"settings_store"`
 	} `json:"pleroma"`
 }
 
+// MuteExpiry returns when the mute of an account returned by GetMutes
+// expires, or the zero time if the mute is indefinite.
+func (a *Account) MuteExpiry() time.Time {
+	if !a.MuteExpiresAt.IsZero() {
+		return a.MuteExpiresAt
+	}
+	if a.Pleroma != nil && a.Pleroma.MuteExpiresAt != nil {
+		return *a.Pleroma.MuteExpiresAt
+	}
+	return time.Time{}
+}
+
 // Field is a Mastodon account profile field.
 type Field struct {
 	Name       string    `json:"name"`
@@ -141,6 +160,16 @@ type Profile struct {
 	// Set the base64 encoded character string of the image.
 	Avatar string
 	Header string
+
+	// Upload the image as a file instead, which takes precedence over
+	// Avatar and Header. If it's an *os.File, its name is sent as well.
+	AvatarFile io.Reader
+	HeaderFile io.Reader
+
+	// Pleroma frontend settings by frontend name. Pleroma merges these
+	// into the stored settings, replacing only the frontends given here.
+	// They can't be sent with AvatarFile or HeaderFile.
+	SettingsStore map[string]interface{}
 }
 
 // AccountUpdate updates the information of the current user.
@@ -179,14 +208,104 @@ func (c *Client) AccountUpdate(ctx context.Context, profile *Profile) (*Account,
 		params.Set("header", profile.Header)
 	}
 
+	var body interface{} = params
+	if profile.SettingsStore != nil {
+		if profile.AvatarFile != nil || profile.HeaderFile != nil {
+			return nil, errors.New("settings store can't be sent with files")
+		}
+		// The settings keep their types only in a JSON body.
+		obj := nestParams(params)
+		obj["pleroma_settings_store"] = profile.SettingsStore
+		b, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		body = json.RawMessage(b)
+	} else if profile.AvatarFile != nil || profile.HeaderFile != nil {
+		form := &multipartForm{values: params}
+		if profile.AvatarFile != nil {
+			params.Del("avatar")
+			form.files = append(form.files, multipartFile{"avatar", profile.AvatarFile})
+		}
+		if profile.HeaderFile != nil {
+			params.Del("header")
+			form.files = append(form.files, multipartFile{"header", profile.HeaderFile})
+		}
+		body = form
+	}
+
 	var account Account
-	err := c.doAPI(ctx, http.MethodPatch, "/api/v1/accounts/update_credentials", params, &account, nil)
+	err := c.doAPI(ctx, http.MethodPatch, "/api/v1/accounts/update_credentials", body, &account, nil)
 	if err != nil {
 		return nil, err
 	}
 	return &account, nil
 }
 
+// DeleteAvatar removes the current user's avatar.
+func (c *Client) DeleteAvatar(ctx context.Context) (*Account, error) {
+	var account Account
+	err := c.doAPI(ctx, http.MethodDelete, "/api/v1/profile/avatar", nil, &account, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// DeleteHeader removes the current user's header image.
+func (c *Client) DeleteHeader(ctx context.Context) (*Account, error) {
+	var account Account
+	err := c.doAPI(ctx, http.MethodDelete, "/api/v1/profile/header", nil, &account, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Registration is a struct for registering accounts.
+type Registration struct {
+	Username  string
+	Email     string
+	Password  string
+	Agreement bool   // Whether the user agrees to the instance's rules and policies.
+	Locale    string // The language of the confirmation e-mail, "en" if empty.
+	Reason    string // Required if registrations need approval.
+}
+
+// RegisterAccount creates a new account. The client must be authenticated
+// with an app token from AuthenticateApp. On success, the access token of
+// the new account replaces it, as with Authenticate. The account may not be
+// usable until its e-mail is confirmed or it's approved.
+func (c *Client) RegisterAccount(ctx context.Context, reg *Registration) error {
+	if reg == nil {
+		return errors.New("registration can't be nil")
+	}
+
+	locale := reg.Locale
+	if locale == "" {
+		locale = "en"
+	}
+
+	params := url.Values{}
+	params.Set("username", reg.Username)
+	params.Set("email", reg.Email)
+	params.Set("password", reg.Password)
+	params.Set("agreement", strconv.FormatBool(reg.Agreement))
+	params.Set("locale", locale)
+	stradd := addParamString(&params)
+	stradd("reason", reg.Reason)
+
+	var res struct {
+		AccessToken string `json:"access_token"`
+	}
+	err := c.doAPI(ctx, http.MethodPost, "/api/v1/accounts", params, &res, nil)
+	if err != nil {
+		return err
+	}
+	c.Config.AccessToken = res.AccessToken
+	return nil
+}
+
 // GetAccountStatuses return statuses by specified account.
 //
 // Deprecated: Does not support modern options, use GetAcctStatuses.
@@ -278,17 +397,20 @@ func (c *Client) GetBlocks(ctx context.Context, pg *Pagination) ([]*Account, err
 
 // Relationship holds information for relationship to the account.
 type Relationship struct {
-	ID                  ID   `json:"id"`
-	Following           bool `json:"following"`
-	FollowedBy          bool `json:"followed_by"`
-	Blocking            bool `json:"blocking"`
-	BlockedBy           bool `json:"blocked_by"`
-	Muting              bool `json:"muting"`
-	MutingNotifications bool `json:"muting_notifications"`
-	Requested           bool `json:"requested"`
-	DomainBlocking      bool `json:"domain_blocking"`
-	ShowingReblogs      bool `json:"showing_reblogs"`
-	Endorsed            bool `json:"endorsed"`
+	ID                  ID       `json:"id"`
+	Following           bool     `json:"following"`
+	FollowedBy          bool     `json:"followed_by"`
+	Blocking            bool     `json:"blocking"`
+	BlockedBy           bool     `json:"blocked_by"`
+	Muting              bool     `json:"muting"`
+	MutingNotifications bool     `json:"muting_notifications"`
+	Requested           bool     `json:"requested"`
+	DomainBlocking      bool     `json:"domain_blocking"`
+	ShowingReblogs      bool     `json:"showing_reblogs"`
+	Endorsed            bool     `json:"endorsed"`
+	Notifying           bool     `json:"notifying"`
+	Languages           []string `json:"languages"`
+	Note                string   `json:"note"`
 
 	// Pleroma
 	Subscribing bool `json:"subscribing"`
@@ -425,6 +547,21 @@ func (c *Client) AccountsSearch(ctx context.Context, q string, limit int64) ([]*
 	return accounts, nil
 }
 
+// LookupAccount returns the Account for a webfinger address, such as
+// "user" for local accounts or "user@host" for remote accounts, without
+// resolving it on the remote server.
+func (c *Client) LookupAccount(ctx context.Context, acct string) (*Account, error) {
+	params := url.Values{}
+	params.Set("acct", strings.TrimPrefix(acct, "@"))
+
+	var account Account
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/accounts/lookup", params, &account, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
 // FollowRemoteUser sends follow-request.
 func (c *Client) FollowRemoteUser(ctx context.Context, uri string) (*Account, error) {
 	params := url.Values{}
@@ -467,3 +604,143 @@ func (c *Client) GetMutes(ctx context.Context, pg *Pagination) ([]*Account, erro
 	}
 	return accounts, nil
 }
+
+// GetDomainBlocks returns the domains blocked by the current user.
+func (c *Client) GetDomainBlocks(ctx context.Context, pg *Pagination) ([]string, error) {
+	var domains []string
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/domain_blocks", nil, &domains, pg)
+	if err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+// BlockDomain hides all content from a domain, and removes any followers from it.
+func (c *Client) BlockDomain(ctx context.Context, domain string) error {
+	params := url.Values{}
+	params.Set("domain", domain)
+
+	return c.doAPI(ctx, http.MethodPost, "/api/v1/domain_blocks", params, nil, nil)
+}
+
+// UnblockDomain removes a domain block.
+func (c *Client) UnblockDomain(ctx context.Context, domain string) error {
+	params := url.Values{}
+	params.Set("domain", domain)
+
+	return c.doAPI(ctx, http.MethodDelete, "/api/v1/domain_blocks", params, nil, nil)
+}
+
+// GetEndorsements returns the accounts featured on the current user's profile.
+func (c *Client) GetEndorsements(ctx context.Context, pg *Pagination) ([]*Account, error) {
+	var accounts []*Account
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/endorsements", nil, &accounts, pg)
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// PinAccount features the account on the current user's profile.
+func (c *Client) PinAccount(ctx context.Context, id ID) (*Relationship, error) {
+	var relationship Relationship
+	err := c.doAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/accounts/%s/pin", url.PathEscape(string(id))), nil, &relationship, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &relationship, nil
+}
+
+// UnpinAccount stops featuring the account on the current user's profile.
+func (c *Client) UnpinAccount(ctx context.Context, id ID) (*Relationship, error) {
+	var relationship Relationship
+	err := c.doAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/accounts/%s/unpin", url.PathEscape(string(id))), nil, &relationship, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &relationship, nil
+}
+
+// SetAccountNote sets a private note on the account, only visible to the
+// current user. An empty comment removes the note.
+func (c *Client) SetAccountNote(ctx context.Context, id ID, comment string) (*Relationship, error) {
+	params := url.Values{}
+	params.Set("comment", comment)
+
+	var relationship Relationship
+	err := c.doAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/accounts/%s/note", url.PathEscape(string(id))), params, &relationship, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &relationship, nil
+}
+
+// FamiliarFollowers holds the accounts followed by the current user that
+// also follow the account with ID.
+type FamiliarFollowers struct {
+	ID       ID         `json:"id"`
+	Accounts []*Account `json:"accounts"`
+}
+
+// GetFamiliarFollowers returns the followers of each account that the
+// current user also follows.
+func (c *Client) GetFamiliarFollowers(ctx context.Context, ids []ID) ([]*FamiliarFollowers, error) {
+	params := url.Values{}
+	for _, id := range ids {
+		params.Add("id[]", id)
+	}
+
+	var familiar []*FamiliarFollowers
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/accounts/familiar_followers", params, &familiar, nil)
+	if err != nil {
+		return nil, err
+	}
+	return familiar, nil
+}
+
+// RemoveFromFollowers makes the account stop following the current user.
+func (c *Client) RemoveFromFollowers(ctx context.Context, id ID) (*Relationship, error) {
+	var relationship Relationship
+	err := c.doAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/accounts/%s/remove_from_followers", url.PathEscape(string(id))), nil, &relationship, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &relationship, nil
+}
+
+// PlGetAccountFavourites returns the statuses favourited by an account, if
+// the account doesn't hide them.
+func (c *Client) PlGetAccountFavourites(ctx context.Context, id ID, pg *Pagination) ([]*Status, error) {
+	var statuses []*Status
+	err := c.doAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v1/pleroma/accounts/%s/favourites", url.PathEscape(string(id))), nil, &statuses, pg)
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// PlGetAccountEndorsements returns the accounts featured on an account's
+// profile.
+func (c *Client) PlGetAccountEndorsements(ctx context.Context, id ID) ([]*Account, error) {
+	var accounts []*Account
+	err := c.doAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v1/pleroma/accounts/%s/endorsements", url.PathEscape(string(id))), nil, &accounts, nil)
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// PlGetBirthdays returns the followed accounts with a birthday on a day of a
+// month, which are 1-based.
+func (c *Client) PlGetBirthdays(ctx context.Context, day, month int) ([]*Account, error) {
+	params := url.Values{}
+	params.Set("day", strconv.Itoa(day))
+	params.Set("month", strconv.Itoa(month))
+
+	var accounts []*Account
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/pleroma/birthdays", params, &accounts, nil)
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
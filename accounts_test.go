@@ -2,9 +2,13 @@ package masta
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 )
@@ -111,6 +115,145 @@ func TestAccountUpdate(t *testing.T) {
 	}
 }
 
+func TestAccountUpdateFiles(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v1/accounts/update_credentials" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.FormValue("display_name") != "spider" || r.FormValue("header") != "data:image/png;base64,AAAA" {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		if _, ok := r.MultipartForm.Value["avatar"]; ok {
+			http.Error(w, "avatar should only be sent as a file", http.StatusBadRequest)
+			return
+		}
+		file, _, err := r.FormFile("avatar")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		b, _ := io.ReadAll(file)
+		fmt.Fprintf(w, `{"username": "zzz", "avatar": %q}`, string(b))
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	a, err := client.AccountUpdate(context.Background(), &Profile{
+		DisplayName: String("spider"),
+		Avatar:      "data:image/png;base64,BBBB",
+		Header:      "data:image/png;base64,AAAA",
+		AvatarFile:  strings.NewReader("avatar image"),
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if a.Avatar != "avatar image" {
+		t.Fatalf("want %q but %q", "avatar image", a.Avatar)
+	}
+}
+
+func TestDeleteAvatarAndHeader(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/profile/avatar":
+			fmt.Fprintln(w, `{"username": "zzz", "avatar": "https://example.com/avatars/original/missing.png"}`)
+		case "/api/v1/profile/header":
+			fmt.Fprintln(w, `{"username": "zzz", "header": "https://example.com/headers/original/missing.png"}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	a, err := client.DeleteAvatar(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if a.Avatar != "https://example.com/avatars/original/missing.png" {
+		t.Fatalf("want %q but %q", "https://example.com/avatars/original/missing.png", a.Avatar)
+	}
+	a, err = client.DeleteHeader(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if a.Header != "https://example.com/headers/original/missing.png" {
+		t.Fatalf("want %q but %q", "https://example.com/headers/original/missing.png", a.Header)
+	}
+}
+
+func TestRegisterAccount(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/accounts" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer apptoken" {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		if r.PostFormValue("agreement") != "true" {
+			http.Error(w, `{"error": "Validation failed: Agreement must be accepted"}`, http.StatusUnprocessableEntity)
+			return
+		}
+		if r.PostFormValue("username") != "spider" || r.PostFormValue("locale") != "en" || r.PostFormValue("reason") != "I like spiders" {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, `{"access_token": "usertoken", "token_type": "Bearer", "scope": "read write follow push", "created_at": 1573979017}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "apptoken",
+	})
+	reg := &Registration{
+		Username: "spider",
+		Email:    "spider@example.com",
+		Password: "hunter2",
+		Reason:   "I like spiders",
+	}
+	err := client.RegisterAccount(context.Background(), reg)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	if client.Config.AccessToken != "apptoken" {
+		t.Fatalf("want %q but %q", "apptoken", client.Config.AccessToken)
+	}
+	reg.Agreement = true
+	err = client.RegisterAccount(context.Background(), reg)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if client.Config.AccessToken != "usertoken" {
+		t.Fatalf("want %q but %q", "usertoken", client.Config.AccessToken)
+	}
+}
+
 func TestGetAccountStatuses(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/api/v1/accounts/1234567/statuses" {
@@ -547,6 +690,38 @@ func TestAccountsSearch(t *testing.T) {
 	}
 }
 
+func TestLookupAccount(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/accounts/lookup" || r.FormValue("acct") != "foo@success.social" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `{"id": "1234", "username": "foo", "acct": "foo@success.social"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	_, err := client.LookupAccount(context.Background(), "foo@fail.social")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	a, err := client.LookupAccount(context.Background(), "@foo@success.social")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if a.ID != "1234" {
+		t.Fatalf("want %q but %q", "1234", a.ID)
+	}
+	if a.Acct != "foo@success.social" {
+		t.Fatalf("want %q but %q", "foo@success.social", a.Acct)
+	}
+}
+
 func TestFollowRemoteUser(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.PostFormValue("uri") != "foo@success.social" {
@@ -697,3 +872,333 @@ func TestGetMutes(t *testing.T) {
 		t.Fatalf("want %q but %q", "bar", mutes[1].Username)
 	}
 }
+
+func TestDomainBlocks(t *testing.T) {
+	blocked := map[string]bool{"bad.example.com": true}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/domain_blocks" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			var domains []string
+			for domain := range blocked {
+				domains = append(domains, domain)
+			}
+			json.NewEncoder(w).Encode(domains)
+		case http.MethodPost:
+			blocked[r.PostFormValue("domain")] = true
+		case http.MethodDelete:
+			// Request bodies of DELETE aren't parsed by ParseForm.
+			b, _ := io.ReadAll(r.Body)
+			params, _ := url.ParseQuery(string(b))
+			if !blocked[params.Get("domain")] {
+				http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+				return
+			}
+			delete(blocked, params.Get("domain"))
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	domains, err := client.GetDomainBlocks(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(domains) != 1 || domains[0] != "bad.example.com" {
+		t.Fatalf("want %v but %v", []string{"bad.example.com"}, domains)
+	}
+	err = client.BlockDomain(context.Background(), "worse.example.com")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if !blocked["worse.example.com"] {
+		t.Fatal("worse.example.com should be blocked")
+	}
+	err = client.UnblockDomain(context.Background(), "good.example.com")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	err = client.UnblockDomain(context.Background(), "bad.example.com")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if blocked["bad.example.com"] {
+		t.Fatal("bad.example.com should not be blocked")
+	}
+}
+
+func TestEndorsements(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/endorsements":
+			fmt.Fprintln(w, `[{"username": "foo"}, {"username": "bar"}]`)
+		case "/api/v1/accounts/1234/pin":
+			fmt.Fprintln(w, `{"id": "1234", "endorsed": true}`)
+		case "/api/v1/accounts/1234/unpin":
+			fmt.Fprintln(w, `{"id": "1234", "endorsed": false}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	accounts, err := client.GetEndorsements(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(accounts) != 2 {
+		t.Fatalf("result should be two: %d", len(accounts))
+	}
+	_, err = client.PinAccount(context.Background(), "123")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	rel, err := client.PinAccount(context.Background(), "1234")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if !rel.Endorsed {
+		t.Fatal("want endorsed")
+	}
+	rel, err = client.UnpinAccount(context.Background(), "1234")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if rel.Endorsed {
+		t.Fatal("want not endorsed")
+	}
+}
+
+func TestSetAccountNote(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/accounts/1234/note" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"id": "1234", "note": %q, "notifying": true, "languages": ["en", "sv"], "domain_blocking": true}`, r.PostFormValue("comment"))
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	_, err := client.SetAccountNote(context.Background(), "123", "foo")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	rel, err := client.SetAccountNote(context.Background(), "1234", "met at the spider convention")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if rel.Note != "met at the spider convention" {
+		t.Fatalf("want %q but %q", "met at the spider convention", rel.Note)
+	}
+	if !rel.Notifying || !rel.DomainBlocking {
+		t.Fatalf("want notifying and domain blocking but %+v", rel)
+	}
+	if len(rel.Languages) != 2 || rel.Languages[1] != "sv" {
+		t.Fatalf("want %v but %v", []string{"en", "sv"}, rel.Languages)
+	}
+}
+
+func TestGetFamiliarFollowers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/accounts/familiar_followers" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		r.ParseForm()
+		if len(r.Form["id[]"]) != 2 {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, `[{"id": "1", "accounts": [{"username": "foo"}]}, {"id": "2", "accounts": []}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	_, err := client.GetFamiliarFollowers(context.Background(), []ID{"1"})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	familiar, err := client.GetFamiliarFollowers(context.Background(), []ID{"1", "2"})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(familiar) != 2 {
+		t.Fatalf("result should be two: %d", len(familiar))
+	}
+	if familiar[0].Accounts[0].Username != "foo" {
+		t.Fatalf("want %q but %q", "foo", familiar[0].Accounts[0].Username)
+	}
+}
+
+func TestRemoveFromFollowers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/accounts/1234/remove_from_followers" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `{"id": "1234", "followed_by": false}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	_, err := client.RemoveFromFollowers(context.Background(), "123")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	rel, err := client.RemoveFromFollowers(context.Background(), "1234")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if rel.FollowedBy {
+		t.Fatal("want not followed by")
+	}
+}
+
+func TestPlAccountExtensions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/pleroma/accounts/1234/favourites":
+			fmt.Fprintln(w, `[{"id": "1", "content": "foo"}, {"id": "2", "content": "bar"}]`)
+		case "/api/v1/pleroma/accounts/1234/endorsements":
+			fmt.Fprintln(w, `[{"username": "foo"}]`)
+		case "/api/v1/pleroma/birthdays":
+			if r.URL.Query().Get("day") != "9" || r.URL.Query().Get("month") != "11" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `[{"username": "foo", "pleroma": {"birthday": "2000-11-09"}}]`)
+		case "/api/v1/mutes":
+			fmt.Fprintln(w, `[{"username": "foo", "mute_expires_at": "2022-11-09T12:00:00.000Z"}, {"username": "bar", "pleroma": {"mute_expires_at": "2022-11-10T12:00:00.000Z"}}, {"username": "baz"}]`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	ctx := context.Background()
+
+	statuses, err := client.PlGetAccountFavourites(ctx, "1234", nil)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(statuses) != 2 || statuses[1].Content != "bar" {
+		t.Fatalf("want foo and bar but %v", statuses)
+	}
+	if _, err := client.PlGetAccountFavourites(ctx, "123", nil); err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+
+	accounts, err := client.PlGetAccountEndorsements(ctx, "1234")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(accounts) != 1 || accounts[0].Username != "foo" {
+		t.Fatalf("want foo but %v", accounts)
+	}
+
+	accounts, err = client.PlGetBirthdays(ctx, 9, 11)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(accounts) != 1 || accounts[0].Pleroma == nil || accounts[0].Pleroma.Birthday == nil || *accounts[0].Pleroma.Birthday != "2000-11-09" {
+		t.Fatalf("want birthday on 2000-11-09 but %v", accounts)
+	}
+
+	mutes, err := client.GetMutes(ctx, nil)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(mutes) != 3 {
+		t.Fatalf("result should be three: %d", len(mutes))
+	}
+	if want := time.Date(2022, 11, 9, 12, 0, 0, 0, time.UTC); !mutes[0].MuteExpiry().Equal(want) {
+		t.Fatalf("want %v but %v", want, mutes[0].MuteExpiry())
+	}
+	if want := time.Date(2022, 11, 10, 12, 0, 0, 0, time.UTC); !mutes[1].MuteExpiry().Equal(want) {
+		t.Fatalf("want %v but %v", want, mutes[1].MuteExpiry())
+	}
+	if !mutes[2].MuteExpiry().IsZero() {
+		t.Fatalf("want no expiry but %v", mutes[2].MuteExpiry())
+	}
+}
+
+func TestAccountUpdateSettingsStore(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			DisplayName string `json:"display_name"`
+			Source      struct {
+				Privacy string `json:"privacy"`
+			} `json:"source"`
+			Store map[string]map[string]interface{} `json:"pleroma_settings_store"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if body.DisplayName != "foo" || body.Source.Privacy != "unlisted" || body.Store["masta"]["columns"] != float64(3) {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, `{"username": "zzz"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	_, err := client.AccountUpdate(context.Background(), &Profile{
+		DisplayName:   String("foo"),
+		Source:        &AccountSource{Privacy: String("unlisted")},
+		SettingsStore: map[string]interface{}{"masta": map[string]interface{}{"columns": 3}},
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+
+	_, err = client.AccountUpdate(context.Background(), &Profile{
+		AvatarFile:    strings.NewReader("png"),
+		SettingsStore: map[string]interface{}{"masta": nil},
+	})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+package masta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// AdminClient is a client for the Mastodon admin API. The access token must
+// have the admin scopes and belong to a moderator or administrator.
+type AdminClient struct {
+	client *Client
+}
+
+// Admin returns a client for the admin API.
+func (c *Client) Admin() *AdminClient { return &AdminClient{client: c} }
+
+// adminPath returns the path of an admin API resource with an ID.
+func adminPath(resource string, id ID) string {
+	return fmt.Sprintf("/api/v1/admin/%s/%s", resource, url.PathEscape(string(id)))
+}
+
+// Convenience constants for AdminAccountsOpts
+const (
+	AdminOriginLocal  = "local"
+	AdminOriginRemote = "remote"
+
+	AdminStatusActive    = "active"
+	AdminStatusPending   = "pending"
+	AdminStatusDisabled  = "disabled"
+	AdminStatusSilenced  = "silenced"
+	AdminStatusSuspended = "suspended"
+
+	AdminPermissionsStaff = "staff"
+)
+
+// Convenience constants for AdminAccountAction.Type
+const (
+	AdminActionNone      = "none"
+	AdminActionSensitive = "sensitive"
+	AdminActionDisable   = "disable"
+	AdminActionSilence   = "silence"
+	AdminActionSuspend   = "suspend"
+)
+
+// AdminAccount holds information for an account as seen by moderators.
+type AdminAccount struct {
+	ID                     ID        `json:"id"`
+	Username               string    `json:"username"`
+	Domain                 string    `json:"domain"`
+	CreatedAt              time.Time `json:"created_at"`
+	Email                  string    `json:"email"`
+	IP                     string    `json:"ip"`
+	IPs                    []AdminIP `json:"ips"`
+	Locale                 string    `json:"locale"`
+	InviteRequest          string    `json:"invite_request"`
+	Role                   AdminRole `json:"role"`
+	Confirmed              bool      `json:"confirmed"`
+	Approved               bool      `json:"approved"`
+	Disabled               bool      `json:"disabled"`
+	Silenced               bool      `json:"silenced"`
+	Suspended              bool      `json:"suspended"`
+	Sensitized             bool      `json:"sensitized"`
+	Account                *Account  `json:"account"`
+	CreatedByApplicationID ID        `json:"created_by_application_id"`
+	InvitedByAccountID     ID        `json:"invited_by_account_id"`
+}
+
+// AdminIP holds an IP address used by an account.
+type AdminIP struct {
+	IP     string    `json:"ip"`
+	UsedAt time.Time `json:"used_at"`
+}
+
+// AdminRole holds the role of an account. Servers before Mastodon 4.0 only
+// send the name of the role, in which case only Name is set.
+type AdminRole struct {
+	ID          ID     `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Permissions Sint   `json:"permissions"`
+	Highlighted bool   `json:"highlighted"`
+}
+
+func (r *AdminRole) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &r.Name)
+	}
+	type role AdminRole
+	return json.Unmarshal(data, (*role)(r))
+}
+
+// AdminAccountsOpts holds the filters for AdminClient.GetAccounts.
+type AdminAccountsOpts struct {
+	Origin      string // One of AdminOriginLocal or AdminOriginRemote.
+	Status      string // One of the AdminStatus constants.
+	Permissions string // AdminPermissionsStaff to only list staff.
+	RoleIDs     []ID   // Only list accounts with these roles.
+	InvitedBy   ID     // Only list accounts invited by this account.
+	Username    string // Search by username.
+	DisplayName string // Search by display name.
+	ByDomain    string // Only list accounts from this domain.
+	Email       string // Search by email address.
+	IP          string // Search by IP address or CIDR range.
+
+	*Pagination
+}
+
+func (a AdminAccountsOpts) params() url.Values {
+	params := &url.Values{}
+
+	stradd := addParamString(params)
+	stradd("origin", a.Origin)
+	stradd("status", a.Status)
+	stradd("permissions", a.Permissions)
+	for _, id := range a.RoleIDs {
+		params.Add("role_ids[]", string(id))
+	}
+	stradd("invited_by", string(a.InvitedBy))
+	stradd("username", a.Username)
+	stradd("display_name", a.DisplayName)
+	stradd("by_domain", a.ByDomain)
+	stradd("email", a.Email)
+	stradd("ip", a.IP)
+
+	return *params
+}
+
+// AdminAccountAction holds a moderation action against an account.
+type AdminAccountAction struct {
+	Type                string // One of the AdminAction constants.
+	ReportID            ID     // Resolve this report with the action.
+	WarningPresetID     ID     // Use the text of this warning preset.
+	Text                string // Additional text for the warning.
+	NoEmailNotification bool   // Don't email the user about the action.
+}
+
+// GetAccounts returns the accounts matching opts.
+func (a *AdminClient) GetAccounts(ctx context.Context, opts AdminAccountsOpts) ([]*AdminAccount, error) {
+	var accounts []*AdminAccount
+	err := a.client.doAPI(ctx, http.MethodGet, "/api/v2/admin/accounts", opts.params(), &accounts, opts.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// GetAccount returns an account.
+func (a *AdminClient) GetAccount(ctx context.Context, id ID) (*AdminAccount, error) {
+	var account AdminAccount
+	err := a.client.doAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v1/admin/accounts/%s", url.PathEscape(string(id))), nil, &account, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (a *AdminClient) accountAction(ctx context.Context, method string, id ID, action string) (*AdminAccount, error) {
+	uri := fmt.Sprintf("/api/v1/admin/accounts/%s", url.PathEscape(string(id)))
+	if action != "" {
+		uri += "/" + action
+	}
+
+	var account AdminAccount
+	err := a.client.doAPI(ctx, method, uri, nil, &account, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// ApproveAccount approves a pending account.
+func (a *AdminClient) ApproveAccount(ctx context.Context, id ID) (*AdminAccount, error) {
+	return a.accountAction(ctx, http.MethodPost, id, "approve")
+}
+
+// RejectAccount rejects and deletes a pending account.
+func (a *AdminClient) RejectAccount(ctx context.Context, id ID) (*AdminAccount, error) {
+	return a.accountAction(ctx, http.MethodPost, id, "reject")
+}
+
+// EnableAccount re-enables a disabled account.
+func (a *AdminClient) EnableAccount(ctx context.Context, id ID) (*AdminAccount, error) {
+	return a.accountAction(ctx, http.MethodPost, id, "enable")
+}
+
+// UnsilenceAccount lifts a silence from an account.
+func (a *AdminClient) UnsilenceAccount(ctx context.Context, id ID) (*AdminAccount, error) {
+	return a.accountAction(ctx, http.MethodPost, id, "unsilence")
+}
+
+// UnsuspendAccount lifts a suspension from an account.
+func (a *AdminClient) UnsuspendAccount(ctx context.Context, id ID) (*AdminAccount, error) {
+	return a.accountAction(ctx, http.MethodPost, id, "unsuspend")
+}
+
+// UnsensitiveAccount stops forcing the media of an account to be sensitive.
+func (a *AdminClient) UnsensitiveAccount(ctx context.Context, id ID) (*AdminAccount, error) {
+	return a.accountAction(ctx, http.MethodPost, id, "unsensitive")
+}
+
+// DeleteAccount permanently deletes the data of a suspended account.
+func (a *AdminClient) DeleteAccount(ctx context.Context, id ID) (*AdminAccount, error) {
+	return a.accountAction(ctx, http.MethodDelete, id, "")
+}
+
+// AccountAction performs a moderation action against an account.
+func (a *AdminClient) AccountAction(ctx context.Context, id ID, action AdminAccountAction) error {
+	params := url.Values{}
+	stradd := addParamString(&params)
+	stradd("type", action.Type)
+	stradd("report_id", string(action.ReportID))
+	stradd("warning_preset_id", string(action.WarningPresetID))
+	stradd("text", action.Text)
+	if action.NoEmailNotification {
+		params.Set("send_email_notification", "false")
+	}
+
+	return a.client.doAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/admin/accounts/%s/action", url.PathEscape(string(id))), params, nil, nil)
+}
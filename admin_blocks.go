@@ -0,0 +1,503 @@
+package masta
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Convenience constants for AdminIPBlock.Severity
+const (
+	IPBlockSignUpRequiresApproval = "sign_up_requires_approval"
+	IPBlockSignUpBlock            = "sign_up_block"
+	IPBlockNoAccess               = "no_access"
+)
+
+// AdminDomainBlock holds information for a domain blocked by the instance.
+type AdminDomainBlock struct {
+	ID             ID        `json:"id"`
+	Domain         string    `json:"domain"`
+	Digest         string    `json:"digest"`
+	CreatedAt      time.Time `json:"created_at"`
+	Severity       string    `json:"severity"`
+	RejectMedia    bool      `json:"reject_media"`
+	RejectReports  bool      `json:"reject_reports"`
+	PrivateComment string    `json:"private_comment"`
+	PublicComment  string    `json:"public_comment"`
+	Obfuscate      bool      `json:"obfuscate"`
+}
+
+func (b *AdminDomainBlock) params() url.Values {
+	params := url.Values{}
+	stradd := addParamString(&params)
+	stradd("domain", b.Domain)
+	stradd("severity", b.Severity)
+	params.Set("reject_media", strconv.FormatBool(b.RejectMedia))
+	params.Set("reject_reports", strconv.FormatBool(b.RejectReports))
+	params.Set("private_comment", b.PrivateComment)
+	params.Set("public_comment", b.PublicComment)
+	params.Set("obfuscate", strconv.FormatBool(b.Obfuscate))
+	return params
+}
+
+// AdminDomainAllow holds a domain allowed to federate with the instance
+// when it's in allowlist mode.
+type AdminDomainAllow struct {
+	ID        ID        `json:"id"`
+	Domain    string    `json:"domain"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AdminIPBlock holds an IP address or range with restricted access.
+type AdminIPBlock struct {
+	ID        ID        `json:"id"`
+	IP        string    `json:"ip"`
+	Severity  string    `json:"severity"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (b *AdminIPBlock) params() url.Values {
+	params := url.Values{}
+	stradd := addParamString(&params)
+	stradd("ip", b.IP)
+	stradd("severity", b.Severity)
+	params.Set("comment", b.Comment)
+	if !b.ExpiresAt.IsZero() {
+		diff := time.Until(b.ExpiresAt)
+		params.Set("expires_in", fmt.Sprintf("%.0f", diff.Seconds()))
+	}
+	return params
+}
+
+// AdminEmailDomainBlock holds an email domain that can't be used to sign up.
+type AdminEmailDomainBlock struct {
+	ID        ID        `json:"id"`
+	Domain    string    `json:"domain"`
+	CreatedAt time.Time `json:"created_at"`
+	History   []History `json:"history"`
+}
+
+// AdminCanonicalEmailBlock holds the hash of a canonicalized email address
+// that can't be used to sign up.
+type AdminCanonicalEmailBlock struct {
+	ID                 ID     `json:"id"`
+	CanonicalEmailHash string `json:"canonical_email_hash"`
+}
+
+// GetDomainBlocks returns the domains blocked by the instance.
+func (a *AdminClient) GetDomainBlocks(ctx context.Context, pg *Pagination) ([]*AdminDomainBlock, error) {
+	var blocks []*AdminDomainBlock
+	err := a.client.doAPI(ctx, http.MethodGet, "/api/v1/admin/domain_blocks", nil, &blocks, pg)
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// GetDomainBlock returns a blocked domain.
+func (a *AdminClient) GetDomainBlock(ctx context.Context, id ID) (*AdminDomainBlock, error) {
+	var block AdminDomainBlock
+	err := a.client.doAPI(ctx, http.MethodGet, adminPath("domain_blocks", id), nil, &block, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// CreateDomainBlock blocks a domain.
+func (a *AdminClient) CreateDomainBlock(ctx context.Context, block *AdminDomainBlock) (*AdminDomainBlock, error) {
+	if block == nil || block.Domain == "" {
+		return nil, errors.New("domain can't be empty")
+	}
+
+	var created AdminDomainBlock
+	err := a.client.doAPI(ctx, http.MethodPost, "/api/v1/admin/domain_blocks", block.params(), &created, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateDomainBlock changes a domain block. The domain itself can't be changed.
+func (a *AdminClient) UpdateDomainBlock(ctx context.Context, id ID, block *AdminDomainBlock) (*AdminDomainBlock, error) {
+	if block == nil {
+		return nil, errors.New("block can't be nil")
+	}
+	params := block.params()
+	params.Del("domain")
+
+	var updated AdminDomainBlock
+	err := a.client.doAPI(ctx, http.MethodPut, adminPath("domain_blocks", id), params, &updated, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteDomainBlock unblocks a domain.
+func (a *AdminClient) DeleteDomainBlock(ctx context.Context, id ID) error {
+	return a.client.doAPI(ctx, http.MethodDelete, adminPath("domain_blocks", id), nil, nil, nil)
+}
+
+// GetDomainAllows returns the domains allowed to federate with the instance.
+func (a *AdminClient) GetDomainAllows(ctx context.Context, pg *Pagination) ([]*AdminDomainAllow, error) {
+	var allows []*AdminDomainAllow
+	err := a.client.doAPI(ctx, http.MethodGet, "/api/v1/admin/domain_allows", nil, &allows, pg)
+	if err != nil {
+		return nil, err
+	}
+	return allows, nil
+}
+
+// GetDomainAllow returns an allowed domain.
+func (a *AdminClient) GetDomainAllow(ctx context.Context, id ID) (*AdminDomainAllow, error) {
+	var allow AdminDomainAllow
+	err := a.client.doAPI(ctx, http.MethodGet, adminPath("domain_allows", id), nil, &allow, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &allow, nil
+}
+
+// CreateDomainAllow allows a domain to federate with the instance.
+func (a *AdminClient) CreateDomainAllow(ctx context.Context, domain string) (*AdminDomainAllow, error) {
+	params := url.Values{}
+	params.Set("domain", domain)
+
+	var allow AdminDomainAllow
+	err := a.client.doAPI(ctx, http.MethodPost, "/api/v1/admin/domain_allows", params, &allow, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &allow, nil
+}
+
+// DeleteDomainAllow stops allowing a domain to federate with the instance.
+func (a *AdminClient) DeleteDomainAllow(ctx context.Context, id ID) error {
+	return a.client.doAPI(ctx, http.MethodDelete, adminPath("domain_allows", id), nil, nil, nil)
+}
+
+// GetIPBlocks returns the IP addresses and ranges with restricted access.
+func (a *AdminClient) GetIPBlocks(ctx context.Context, pg *Pagination) ([]*AdminIPBlock, error) {
+	var blocks []*AdminIPBlock
+	err := a.client.doAPI(ctx, http.MethodGet, "/api/v1/admin/ip_blocks", nil, &blocks, pg)
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// GetIPBlock returns an IP block.
+func (a *AdminClient) GetIPBlock(ctx context.Context, id ID) (*AdminIPBlock, error) {
+	var block AdminIPBlock
+	err := a.client.doAPI(ctx, http.MethodGet, adminPath("ip_blocks", id), nil, &block, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// CreateIPBlock restricts access from an IP address or CIDR range. If
+// block.ExpiresAt is zero, the block doesn't expire.
+func (a *AdminClient) CreateIPBlock(ctx context.Context, block *AdminIPBlock) (*AdminIPBlock, error) {
+	if block == nil || block.IP == "" {
+		return nil, errors.New("ip can't be empty")
+	}
+	if block.Severity == "" {
+		return nil, errors.New("severity can't be empty")
+	}
+
+	var created AdminIPBlock
+	err := a.client.doAPI(ctx, http.MethodPost, "/api/v1/admin/ip_blocks", block.params(), &created, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateIPBlock changes an IP block.
+func (a *AdminClient) UpdateIPBlock(ctx context.Context, id ID, block *AdminIPBlock) (*AdminIPBlock, error) {
+	if block == nil {
+		return nil, errors.New("block can't be nil")
+	}
+
+	var updated AdminIPBlock
+	err := a.client.doAPI(ctx, http.MethodPut, adminPath("ip_blocks", id), block.params(), &updated, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteIPBlock lifts an IP block.
+func (a *AdminClient) DeleteIPBlock(ctx context.Context, id ID) error {
+	return a.client.doAPI(ctx, http.MethodDelete, adminPath("ip_blocks", id), nil, nil, nil)
+}
+
+// GetEmailDomainBlocks returns the email domains that can't be used to sign up.
+func (a *AdminClient) GetEmailDomainBlocks(ctx context.Context, pg *Pagination) ([]*AdminEmailDomainBlock, error) {
+	var blocks []*AdminEmailDomainBlock
+	err := a.client.doAPI(ctx, http.MethodGet, "/api/v1/admin/email_domain_blocks", nil, &blocks, pg)
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// GetEmailDomainBlock returns a blocked email domain.
+func (a *AdminClient) GetEmailDomainBlock(ctx context.Context, id ID) (*AdminEmailDomainBlock, error) {
+	var block AdminEmailDomainBlock
+	err := a.client.doAPI(ctx, http.MethodGet, adminPath("email_domain_blocks", id), nil, &block, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// CreateEmailDomainBlock blocks sign ups with email addresses from a domain.
+func (a *AdminClient) CreateEmailDomainBlock(ctx context.Context, domain string) (*AdminEmailDomainBlock, error) {
+	params := url.Values{}
+	params.Set("domain", domain)
+
+	var block AdminEmailDomainBlock
+	err := a.client.doAPI(ctx, http.MethodPost, "/api/v1/admin/email_domain_blocks", params, &block, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// DeleteEmailDomainBlock unblocks an email domain.
+func (a *AdminClient) DeleteEmailDomainBlock(ctx context.Context, id ID) error {
+	return a.client.doAPI(ctx, http.MethodDelete, adminPath("email_domain_blocks", id), nil, nil, nil)
+}
+
+// GetCanonicalEmailBlocks returns the blocked canonical email addresses.
+func (a *AdminClient) GetCanonicalEmailBlocks(ctx context.Context, pg *Pagination) ([]*AdminCanonicalEmailBlock, error) {
+	var blocks []*AdminCanonicalEmailBlock
+	err := a.client.doAPI(ctx, http.MethodGet, "/api/v1/admin/canonical_email_blocks", nil, &blocks, pg)
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// GetCanonicalEmailBlock returns a canonical email block.
+func (a *AdminClient) GetCanonicalEmailBlock(ctx context.Context, id ID) (*AdminCanonicalEmailBlock, error) {
+	var block AdminCanonicalEmailBlock
+	err := a.client.doAPI(ctx, http.MethodGet, adminPath("canonical_email_blocks", id), nil, &block, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+func (a *AdminClient) createCanonicalEmailBlock(ctx context.Context, key, value string) (*AdminCanonicalEmailBlock, error) {
+	if value == "" {
+		return nil, fmt.Errorf("%s can't be empty", key)
+	}
+	params := url.Values{}
+	params.Set(key, value)
+
+	var block AdminCanonicalEmailBlock
+	err := a.client.doAPI(ctx, http.MethodPost, "/api/v1/admin/canonical_email_blocks", params, &block, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// CreateCanonicalEmailBlock blocks sign ups with an email address, and all
+// the variations of it that are delivered to the same inbox.
+func (a *AdminClient) CreateCanonicalEmailBlock(ctx context.Context, email string) (*AdminCanonicalEmailBlock, error) {
+	return a.createCanonicalEmailBlock(ctx, "email", email)
+}
+
+// CreateCanonicalEmailBlockHash blocks sign ups with the canonical email
+// address with the given SHA256 hash.
+func (a *AdminClient) CreateCanonicalEmailBlockHash(ctx context.Context, hash string) (*AdminCanonicalEmailBlock, error) {
+	return a.createCanonicalEmailBlock(ctx, "canonical_email_hash", hash)
+}
+
+// DeleteCanonicalEmailBlock lifts a canonical email block.
+func (a *AdminClient) DeleteCanonicalEmailBlock(ctx context.Context, id ID) error {
+	return a.client.doAPI(ctx, http.MethodDelete, adminPath("canonical_email_blocks", id), nil, nil, nil)
+}
+
+// TestCanonicalEmailBlocks returns the canonical email blocks that match
+// an email address.
+func (a *AdminClient) TestCanonicalEmailBlocks(ctx context.Context, email string) ([]*AdminCanonicalEmailBlock, error) {
+	params := url.Values{}
+	params.Set("email", email)
+
+	var blocks []*AdminCanonicalEmailBlock
+	err := a.client.doAPI(ctx, http.MethodPost, "/api/v1/admin/canonical_email_blocks/test", params, &blocks, nil)
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+var domainBlocksCSVHeader = []string{"#domain", "#severity", "#reject_media", "#reject_reports", "#public_comment", "#obfuscate"}
+
+// WriteDomainBlocksCSV writes domain blocks in the CSV format Mastodon
+// uses to export them. Private comments aren't exported.
+func WriteDomainBlocksCSV(w io.Writer, blocks []*AdminDomainBlock) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(domainBlocksCSVHeader); err != nil {
+		return err
+	}
+	for _, b := range blocks {
+		err := cw.Write([]string{
+			b.Domain,
+			b.Severity,
+			strconv.FormatBool(b.RejectMedia),
+			strconv.FormatBool(b.RejectReports),
+			b.PublicComment,
+			strconv.FormatBool(b.Obfuscate),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadDomainBlocksCSV reads domain blocks in the CSV format Mastodon uses to
+// export them. The header row is required, but columns other than
+// "#domain" are optional and may be in any order.
+func ReadDomainBlocksCSV(r io.Reader) ([]*AdminDomainBlock, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errors.New("missing header row")
+	} else if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimPrefix(strings.ToLower(strings.TrimSpace(name)), "#")] = i
+	}
+	if _, ok := columns["domain"]; !ok {
+		return nil, errors.New("missing #domain column")
+	}
+
+	var blocks []*AdminDomainBlock
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return blocks, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		boolField := func(name string) (bool, error) {
+			v := field(name)
+			if v == "" {
+				return false, nil
+			}
+			return strconv.ParseBool(v)
+		}
+
+		b := &AdminDomainBlock{
+			Domain:        field("domain"),
+			Severity:      field("severity"),
+			PublicComment: field("public_comment"),
+		}
+		if b.Domain == "" {
+			continue
+		}
+		if b.RejectMedia, err = boolField("reject_media"); err != nil {
+			return nil, err
+		}
+		if b.RejectReports, err = boolField("reject_reports"); err != nil {
+			return nil, err
+		}
+		if b.Obfuscate, err = boolField("obfuscate"); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, b)
+	}
+}
+
+func (a *AdminClient) getAllDomainBlocks(ctx context.Context) ([]*AdminDomainBlock, error) {
+	var (
+		all   []*AdminDomainBlock
+		maxID ID
+	)
+	for {
+		pg := &Pagination{MaxID: maxID, Limit: 200}
+		blocks, err := a.GetDomainBlocks(ctx, pg)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, blocks...)
+		if len(blocks) == 0 || pg.MaxID == "" || pg.MaxID == maxID {
+			return all, nil
+		}
+		maxID = pg.MaxID
+	}
+}
+
+// ExportDomainBlocks writes all the domains blocked by the instance to w,
+// in the format of WriteDomainBlocksCSV.
+func (a *AdminClient) ExportDomainBlocks(ctx context.Context, w io.Writer) error {
+	blocks, err := a.getAllDomainBlocks(ctx)
+	if err != nil {
+		return err
+	}
+	return WriteDomainBlocksCSV(w, blocks)
+}
+
+// ImportDomainBlocks reads domain blocks from r, in the format of
+// ReadDomainBlocksCSV, and creates the ones for domains that aren't
+// blocked yet. The created blocks are returned, also if an error occurs
+// part way.
+func (a *AdminClient) ImportDomainBlocks(ctx context.Context, r io.Reader) ([]*AdminDomainBlock, error) {
+	blocks, err := ReadDomainBlocksCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := a.getAllDomainBlocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blocked := map[string]bool{}
+	for _, b := range existing {
+		blocked[strings.ToLower(b.Domain)] = true
+	}
+
+	var created []*AdminDomainBlock
+	for _, b := range blocks {
+		if blocked[strings.ToLower(b.Domain)] {
+			continue
+		}
+		c, err := a.CreateDomainBlock(ctx, b)
+		if err != nil {
+			return created, err
+		}
+		blocked[strings.ToLower(b.Domain)] = true
+		created = append(created, c)
+	}
+	return created, nil
+}
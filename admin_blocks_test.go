@@ -0,0 +1,457 @@
+package masta
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAdminDomainBlocks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/admin/domain_blocks":
+			fmt.Fprintln(w, `[{"id": "1", "domain": "example.com", "digest": "a379a6f6eeafb9a55e378c118034e2751e682fab9f2d30ab13d2125586ce1947", "severity": "suspend", "reject_media": false, "reject_reports": false, "private_comment": null, "public_comment": "spam", "obfuscate": false}]`)
+		case "GET /api/v1/admin/domain_blocks/1":
+			fmt.Fprintln(w, `{"id": "1", "domain": "example.com", "severity": "suspend"}`)
+		case "POST /api/v1/admin/domain_blocks":
+			if r.PostFormValue("domain") != "example.org" || r.PostFormValue("severity") != "silence" || r.PostFormValue("reject_media") != "true" || r.PostFormValue("obfuscate") != "false" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintf(w, `{"id": "2", "domain": %q, "severity": %q, "reject_media": true, "private_comment": %q}`, r.PostFormValue("domain"), r.PostFormValue("severity"), r.PostFormValue("private_comment"))
+		case "PUT /api/v1/admin/domain_blocks/2":
+			if _, ok := r.PostForm["domain"]; ok {
+				http.Error(w, "domain should not be set", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintf(w, `{"id": "2", "domain": "example.org", "severity": %q, "reject_media": %s}`, r.PostFormValue("severity"), r.PostFormValue("reject_media"))
+		case "DELETE /api/v1/admin/domain_blocks/2":
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.Admin()
+	ctx := context.Background()
+
+	blocks, err := admin.GetDomainBlocks(ctx, nil)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("result should be one: %d", len(blocks))
+	}
+	if blocks[0].Severity != DomainBlockSuspend || blocks[0].PublicComment != "spam" {
+		t.Fatalf("want %q, %q but %q, %q", DomainBlockSuspend, "spam", blocks[0].Severity, blocks[0].PublicComment)
+	}
+	block, err := admin.GetDomainBlock(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if block.Domain != "example.com" {
+		t.Fatalf("want %q but %q", "example.com", block.Domain)
+	}
+
+	_, err = admin.CreateDomainBlock(ctx, &AdminDomainBlock{})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	block, err = admin.CreateDomainBlock(ctx, &AdminDomainBlock{
+		Domain:         "example.org",
+		Severity:       DomainBlockSilence,
+		RejectMedia:    true,
+		PrivateComment: "noisy",
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if block.ID != "2" || block.PrivateComment != "noisy" || !block.RejectMedia {
+		t.Fatalf("want block %q but %v", "2", block)
+	}
+
+	block.Severity = DomainBlockNoop
+	block.RejectMedia = false
+	block, err = admin.UpdateDomainBlock(ctx, block.ID, block)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if block.Severity != DomainBlockNoop || block.RejectMedia {
+		t.Fatalf("want %q without reject_media but %v", DomainBlockNoop, block)
+	}
+
+	err = admin.DeleteDomainBlock(ctx, "2")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	err = admin.DeleteDomainBlock(ctx, "3")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+}
+
+func TestAdminDomainAllows(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/admin/domain_allows":
+			fmt.Fprintln(w, `[{"id": "1", "domain": "example.com", "created_at": "2022-09-14T21:23:02.755Z"}]`)
+		case "GET /api/v1/admin/domain_allows/1":
+			fmt.Fprintln(w, `{"id": "1", "domain": "example.com"}`)
+		case "POST /api/v1/admin/domain_allows":
+			fmt.Fprintf(w, `{"id": "2", "domain": %q}`, r.FormValue("domain"))
+		case "DELETE /api/v1/admin/domain_allows/2":
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.Admin()
+	ctx := context.Background()
+
+	allows, err := admin.GetDomainAllows(ctx, nil)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(allows) != 1 || allows[0].CreatedAt.IsZero() {
+		t.Fatalf("want one allow but %v", allows)
+	}
+	allow, err := admin.GetDomainAllow(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if allow.Domain != "example.com" {
+		t.Fatalf("want %q but %q", "example.com", allow.Domain)
+	}
+	allow, err = admin.CreateDomainAllow(ctx, "example.org")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if allow.Domain != "example.org" {
+		t.Fatalf("want %q but %q", "example.org", allow.Domain)
+	}
+	if err := admin.DeleteDomainAllow(ctx, "2"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+}
+
+func TestAdminIPBlocks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/admin/ip_blocks":
+			fmt.Fprintln(w, `[{"id": "1", "ip": "8.8.8.8/32", "severity": "no_access", "comment": "", "created_at": "2022-11-16T07:22:00.501Z", "expires_at": null}]`)
+		case "GET /api/v1/admin/ip_blocks/1":
+			fmt.Fprintln(w, `{"id": "1", "ip": "8.8.8.8/32", "severity": "no_access"}`)
+		case "POST /api/v1/admin/ip_blocks", "PUT /api/v1/admin/ip_blocks/2":
+			if r.PostFormValue("expires_in") == "" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintf(w, `{"id": "2", "ip": %q, "severity": %q, "comment": %q, "expires_at": "2022-11-17T07:22:00.501Z"}`, r.PostFormValue("ip"), r.PostFormValue("severity"), r.PostFormValue("comment"))
+		case "DELETE /api/v1/admin/ip_blocks/2":
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.Admin()
+	ctx := context.Background()
+
+	blocks, err := admin.GetIPBlocks(ctx, nil)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(blocks) != 1 || blocks[0].Severity != IPBlockNoAccess || !blocks[0].ExpiresAt.IsZero() {
+		t.Fatalf("want one block without expiry but %v", blocks)
+	}
+	block, err := admin.GetIPBlock(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if block.IP != "8.8.8.8/32" {
+		t.Fatalf("want %q but %q", "8.8.8.8/32", block.IP)
+	}
+
+	_, err = admin.CreateIPBlock(ctx, &AdminIPBlock{Severity: IPBlockSignUpBlock})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	_, err = admin.CreateIPBlock(ctx, &AdminIPBlock{IP: "10.0.0.0/8"})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	_, err = admin.CreateIPBlock(ctx, &AdminIPBlock{IP: "10.0.0.0/8", Severity: IPBlockSignUpBlock})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	block, err = admin.CreateIPBlock(ctx, &AdminIPBlock{
+		IP:        "10.0.0.0/8",
+		Severity:  IPBlockSignUpBlock,
+		Comment:   "spam",
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if block.Severity != IPBlockSignUpBlock || block.Comment != "spam" || block.ExpiresAt.IsZero() {
+		t.Fatalf("want block %q but %v", "10.0.0.0/8", block)
+	}
+
+	block.Severity = IPBlockSignUpRequiresApproval
+	block, err = admin.UpdateIPBlock(ctx, block.ID, block)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if block.Severity != IPBlockSignUpRequiresApproval {
+		t.Fatalf("want %q but %q", IPBlockSignUpRequiresApproval, block.Severity)
+	}
+	if err := admin.DeleteIPBlock(ctx, "2"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+}
+
+func TestAdminEmailBlocks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/admin/email_domain_blocks":
+			fmt.Fprintln(w, `[{"id": "1", "domain": "example.com", "history": [{"day": "1668556800", "accounts": "0", "uses": "0"}]}]`)
+		case "GET /api/v1/admin/email_domain_blocks/1":
+			fmt.Fprintln(w, `{"id": "1", "domain": "example.com"}`)
+		case "POST /api/v1/admin/email_domain_blocks":
+			fmt.Fprintf(w, `{"id": "2", "domain": %q}`, r.FormValue("domain"))
+		case "DELETE /api/v1/admin/email_domain_blocks/2", "DELETE /api/v1/admin/canonical_email_blocks/2":
+		case "GET /api/v1/admin/canonical_email_blocks":
+			fmt.Fprintln(w, `[{"id": "1", "canonical_email_hash": "b344e55d11b3fc25d0d53194e0475838bf17e9be67ce3e6469956222d9a34f9c"}]`)
+		case "GET /api/v1/admin/canonical_email_blocks/1":
+			fmt.Fprintln(w, `{"id": "1", "canonical_email_hash": "b344e55d11b3fc25d0d53194e0475838bf17e9be67ce3e6469956222d9a34f9c"}`)
+		case "POST /api/v1/admin/canonical_email_blocks":
+			if r.FormValue("email") != "" {
+				fmt.Fprintln(w, `{"id": "2", "canonical_email_hash": "fromemail"}`)
+			} else {
+				fmt.Fprintf(w, `{"id": "2", "canonical_email_hash": %q}`, r.FormValue("canonical_email_hash"))
+			}
+		case "POST /api/v1/admin/canonical_email_blocks/test":
+			if r.FormValue("email") != "foo@example.com" {
+				fmt.Fprintln(w, `[]`)
+				return
+			}
+			fmt.Fprintln(w, `[{"id": "1", "canonical_email_hash": "b344e55d11b3fc25d0d53194e0475838bf17e9be67ce3e6469956222d9a34f9c"}]`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.Admin()
+	ctx := context.Background()
+
+	domains, err := admin.GetEmailDomainBlocks(ctx, nil)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(domains) != 1 || len(domains[0].History) != 1 {
+		t.Fatalf("want one block with history but %v", domains)
+	}
+	domain, err := admin.GetEmailDomainBlock(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if domain.Domain != "example.com" {
+		t.Fatalf("want %q but %q", "example.com", domain.Domain)
+	}
+	domain, err = admin.CreateEmailDomainBlock(ctx, "example.org")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if domain.Domain != "example.org" {
+		t.Fatalf("want %q but %q", "example.org", domain.Domain)
+	}
+	if err := admin.DeleteEmailDomainBlock(ctx, "2"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+
+	canonical, err := admin.GetCanonicalEmailBlocks(ctx, nil)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(canonical) != 1 {
+		t.Fatalf("result should be one: %d", len(canonical))
+	}
+	block, err := admin.GetCanonicalEmailBlock(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if block.CanonicalEmailHash != canonical[0].CanonicalEmailHash {
+		t.Fatalf("want %q but %q", canonical[0].CanonicalEmailHash, block.CanonicalEmailHash)
+	}
+	_, err = admin.CreateCanonicalEmailBlock(ctx, "")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	block, err = admin.CreateCanonicalEmailBlock(ctx, "foo+bar@example.com")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if block.CanonicalEmailHash != "fromemail" {
+		t.Fatalf("want %q but %q", "fromemail", block.CanonicalEmailHash)
+	}
+	block, err = admin.CreateCanonicalEmailBlockHash(ctx, "abc")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if block.CanonicalEmailHash != "abc" {
+		t.Fatalf("want %q but %q", "abc", block.CanonicalEmailHash)
+	}
+	if err := admin.DeleteCanonicalEmailBlock(ctx, "2"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+
+	matches, err := admin.TestCanonicalEmailBlocks(ctx, "foo@example.com")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("result should be one: %d", len(matches))
+	}
+	matches, err = admin.TestCanonicalEmailBlocks(ctx, "bar@example.com")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(matches) != 0 {
+		t.Fatalf("result should be zero: %d", len(matches))
+	}
+}
+
+func TestDomainBlocksCSV(t *testing.T) {
+	blocks := []*AdminDomainBlock{
+		{Domain: "example.com", Severity: DomainBlockSuspend, RejectMedia: true, RejectReports: true, PublicComment: "spam, mostly", PrivateComment: "secret"},
+		{Domain: "example.org", Severity: DomainBlockSilence, Obfuscate: true},
+	}
+	var buf bytes.Buffer
+	if err := WriteDomainBlocksCSV(&buf, blocks); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	want := "#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate\n" +
+		"example.com,suspend,true,true,\"spam, mostly\",false\n" +
+		"example.org,silence,false,false,,true\n"
+	if buf.String() != want {
+		t.Fatalf("want %q but %q", want, buf.String())
+	}
+
+	read, err := ReadDomainBlocksCSV(&buf)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(read) != 2 {
+		t.Fatalf("result should be two: %d", len(read))
+	}
+	if read[0].PublicComment != "spam, mostly" || !read[0].RejectMedia || read[0].PrivateComment != "" {
+		t.Fatalf("want %v but %v", blocks[0], read[0])
+	}
+	if !read[1].Obfuscate || read[1].Severity != DomainBlockSilence {
+		t.Fatalf("want %v but %v", blocks[1], read[1])
+	}
+
+	read, err = ReadDomainBlocksCSV(strings.NewReader("domain,severity\nexample.net,suspend\n,silence\nexample.edu\n"))
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(read) != 2 || read[0].Domain != "example.net" || read[0].Severity != DomainBlockSuspend || read[1].Severity != "" {
+		t.Fatalf("want two blocks but %v", read)
+	}
+
+	for _, input := range []string{
+		"",
+		"#severity\nsuspend\n",
+		"#domain,#reject_media\nexample.com,maybe\n",
+	} {
+		_, err := ReadDomainBlocksCSV(strings.NewReader(input))
+		if err == nil {
+			t.Fatalf("should be fail: %q", input)
+		}
+	}
+}
+
+func TestAdminImportExportDomainBlocks(t *testing.T) {
+	var created []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/admin/domain_blocks":
+			if r.URL.Query().Get("max_id") == "" {
+				w.Header().Set("Link", `<http://example.com?max_id=2>; rel="next"`)
+				fmt.Fprintln(w, `[{"id": "3", "domain": "example.com", "severity": "suspend"}]`)
+				return
+			}
+			fmt.Fprintln(w, `[{"id": "1", "domain": "Example.org", "severity": "silence", "reject_media": true}]`)
+		case "POST /api/v1/admin/domain_blocks":
+			created = append(created, r.FormValue("domain"))
+			fmt.Fprintf(w, `{"id": "4", "domain": %q, "severity": %q}`, r.FormValue("domain"), r.FormValue("severity"))
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.Admin()
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	if err := admin.ExportDomainBlocks(ctx, &buf); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	want := "#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate\n" +
+		"example.com,suspend,false,false,,false\n" +
+		"Example.org,silence,true,false,,false\n"
+	if buf.String() != want {
+		t.Fatalf("want %q but %q", want, buf.String())
+	}
+
+	blocks, err := admin.ImportDomainBlocks(ctx, strings.NewReader("#domain,#severity\nexample.com,suspend\nexample.org,suspend\nexample.net,silence\nexample.net,suspend\n"))
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(blocks) != 1 || blocks[0].Domain != "example.net" || blocks[0].Severity != DomainBlockSilence {
+		t.Fatalf("want one block for %q but %v", "example.net", blocks)
+	}
+	if len(created) != 1 {
+		t.Fatalf("result should be one: %d", len(created))
+	}
+}
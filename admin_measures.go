@@ -0,0 +1,210 @@
+package masta
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AdminMeasureKey selects a measure for AdminClient.GetMeasures.
+type AdminMeasureKey string
+
+// Keys for AdminMeasuresOpts.Keys. The tag keys require TagID and the
+// instance keys require Domain.
+const (
+	MeasureActiveUsers              AdminMeasureKey = "active_users"
+	MeasureNewUsers                 AdminMeasureKey = "new_users"
+	MeasureInteractions             AdminMeasureKey = "interactions"
+	MeasureOpenedReports            AdminMeasureKey = "opened_reports"
+	MeasureResolvedReports          AdminMeasureKey = "resolved_reports"
+	MeasureTagAccounts              AdminMeasureKey = "tag_accounts"
+	MeasureTagUses                  AdminMeasureKey = "tag_uses"
+	MeasureTagServers               AdminMeasureKey = "tag_servers"
+	MeasureInstanceAccounts         AdminMeasureKey = "instance_accounts"
+	MeasureInstanceMediaAttachments AdminMeasureKey = "instance_media_attachments"
+	MeasureInstanceReports          AdminMeasureKey = "instance_reports"
+	MeasureInstanceStatuses         AdminMeasureKey = "instance_statuses"
+	MeasureInstanceFollows          AdminMeasureKey = "instance_follows"
+	MeasureInstanceFollowers        AdminMeasureKey = "instance_followers"
+)
+
+// AdminDimensionKey selects a dimension for AdminClient.GetDimensions.
+type AdminDimensionKey string
+
+// Keys for AdminDimensionsOpts.Keys. The tag keys require TagID and the
+// instance keys require Domain.
+const (
+	DimensionLanguages         AdminDimensionKey = "languages"
+	DimensionSources           AdminDimensionKey = "sources"
+	DimensionServers           AdminDimensionKey = "servers"
+	DimensionSpaceUsage        AdminDimensionKey = "space_usage"
+	DimensionSoftwareVersions  AdminDimensionKey = "software_versions"
+	DimensionTagServers        AdminDimensionKey = "tag_servers"
+	DimensionTagLanguages      AdminDimensionKey = "tag_languages"
+	DimensionInstanceAccounts  AdminDimensionKey = "instance_accounts"
+	DimensionInstanceLanguages AdminDimensionKey = "instance_languages"
+)
+
+// Convenience constants for AdminClient.GetRetention
+const (
+	RetentionDay   = "day"
+	RetentionMonth = "month"
+)
+
+// AdminMeasure holds the total of a measure over a time range, and its
+// value for each day in it.
+type AdminMeasure struct {
+	Key           AdminMeasureKey    `json:"key"`
+	Unit          string             `json:"unit"`
+	Total         Sint               `json:"total"`
+	HumanValue    string             `json:"human_value"`
+	PreviousTotal Sint               `json:"previous_total"`
+	Data          []AdminMeasureData `json:"data"`
+}
+
+// AdminMeasureData holds the value of a measure on a day.
+type AdminMeasureData struct {
+	Date  time.Time `json:"date"`
+	Value Sint      `json:"value"`
+}
+
+// AdminDimension holds the breakdown of a dimension over a time range.
+type AdminDimension struct {
+	Key  AdminDimensionKey    `json:"key"`
+	Data []AdminDimensionData `json:"data"`
+}
+
+// AdminDimensionData holds a value of a dimension, i.e. a language, and
+// how often it occurred.
+type AdminDimensionData struct {
+	Key        string `json:"key"`
+	HumanKey   string `json:"human_key"`
+	Value      Sint   `json:"value"`
+	Unit       string `json:"unit"`
+	HumanValue string `json:"human_value"`
+}
+
+// AdminCohort holds how many of the users that signed up in a period were
+// still active in the periods after it.
+type AdminCohort struct {
+	Period    time.Time         `json:"period"`
+	Frequency string            `json:"frequency"`
+	Data      []AdminCohortData `json:"data"`
+}
+
+// AdminCohortData holds the retention of a cohort in a period.
+type AdminCohortData struct {
+	Date  time.Time `json:"date"`
+	Rate  float64   `json:"rate"`
+	Value Sint      `json:"value"`
+}
+
+// AdminMeasuresOpts holds the parameters of AdminClient.GetMeasures.
+type AdminMeasuresOpts struct {
+	Keys    []AdminMeasureKey
+	StartAt time.Time
+	EndAt   time.Time
+	TagID   ID     // The tag the tag measures are about.
+	Domain  string // The instance the instance measures are about.
+}
+
+// AdminDimensionsOpts holds the parameters of AdminClient.GetDimensions.
+type AdminDimensionsOpts struct {
+	Keys    []AdminDimensionKey
+	StartAt time.Time
+	EndAt   time.Time
+	Limit   int    // The number of values to return per dimension.
+	TagID   ID     // The tag the tag dimensions are about.
+	Domain  string // The instance the instance dimensions are about.
+}
+
+func timeRangeParams(start, end time.Time) (url.Values, error) {
+	if start.IsZero() || end.IsZero() {
+		return nil, errors.New("time range can't be empty")
+	}
+	if end.Before(start) {
+		return nil, errors.New("time range can't end before it starts")
+	}
+	params := url.Values{}
+	params.Set("start_at", start.UTC().Format(time.RFC3339))
+	params.Set("end_at", end.UTC().Format(time.RFC3339))
+	return params, nil
+}
+
+// addKeyParams adds a key and the tag or instance it is about.
+func addKeyParams(params url.Values, key string, tagID ID, domain string) {
+	params.Add("keys[]", key)
+	switch {
+	case strings.HasPrefix(key, "tag_"):
+		params.Set(key+"[id]", string(tagID))
+	case strings.HasPrefix(key, "instance_"):
+		params.Set(key+"[domain]", domain)
+	}
+}
+
+// GetMeasures returns the totals and daily values of measures.
+func (a *AdminClient) GetMeasures(ctx context.Context, opts AdminMeasuresOpts) ([]*AdminMeasure, error) {
+	if len(opts.Keys) == 0 {
+		return nil, errors.New("keys can't be empty")
+	}
+	params, err := timeRangeParams(opts.StartAt, opts.EndAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range opts.Keys {
+		addKeyParams(params, string(key), opts.TagID, opts.Domain)
+	}
+
+	var measures []*AdminMeasure
+	err = a.client.doAPI(ctx, http.MethodPost, "/api/v1/admin/measures", params, &measures, nil)
+	if err != nil {
+		return nil, err
+	}
+	return measures, nil
+}
+
+// GetDimensions returns the breakdowns of dimensions.
+func (a *AdminClient) GetDimensions(ctx context.Context, opts AdminDimensionsOpts) ([]*AdminDimension, error) {
+	if len(opts.Keys) == 0 {
+		return nil, errors.New("keys can't be empty")
+	}
+	params, err := timeRangeParams(opts.StartAt, opts.EndAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range opts.Keys {
+		addKeyParams(params, string(key), opts.TagID, opts.Domain)
+	}
+	if opts.Limit > 0 {
+		params.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
+	var dimensions []*AdminDimension
+	err = a.client.doAPI(ctx, http.MethodPost, "/api/v1/admin/dimensions", params, &dimensions, nil)
+	if err != nil {
+		return nil, err
+	}
+	return dimensions, nil
+}
+
+// GetRetention returns the retention of the users that signed up between
+// start and end, grouped by RetentionDay or RetentionMonth.
+func (a *AdminClient) GetRetention(ctx context.Context, start, end time.Time, frequency string) ([]*AdminCohort, error) {
+	params, err := timeRangeParams(start, end)
+	if err != nil {
+		return nil, err
+	}
+	stradd := addParamString(&params)
+	stradd("frequency", frequency)
+
+	var cohorts []*AdminCohort
+	err = a.client.doAPI(ctx, http.MethodPost, "/api/v1/admin/retention", params, &cohorts, nil)
+	if err != nil {
+		return nil, err
+	}
+	return cohorts, nil
+}
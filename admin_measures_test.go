@@ -0,0 +1,173 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAdminGetMeasures(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/admin/measures" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		r.ParseForm()
+		if len(r.PostForm["keys[]"]) != 3 || r.PostFormValue("start_at") != "2022-09-01T00:00:00Z" || r.PostFormValue("end_at") != "2022-09-02T00:00:00Z" {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		if r.PostFormValue("tag_uses[id]") != "5" || r.PostFormValue("instance_accounts[domain]") != "example.com" {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		if _, ok := r.PostForm["active_users[id]"]; ok {
+			http.Error(w, "active_users[id] should not be set", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, `[{"key": "active_users", "unit": null, "total": "2", "previous_total": "1", "data": [{"date": "2022-09-01T00:00:00.000+00:00", "value": "1"}, {"date": "2022-09-02T00:00:00.000+00:00", "value": "2"}]}, {"key": "tag_uses", "total": "0", "data": []}, {"key": "instance_accounts", "total": "10", "data": []}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.Admin()
+	ctx := context.Background()
+	start := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	_, err := admin.GetMeasures(ctx, AdminMeasuresOpts{StartAt: start, EndAt: end})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	_, err = admin.GetMeasures(ctx, AdminMeasuresOpts{Keys: []AdminMeasureKey{MeasureActiveUsers}, StartAt: start})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	_, err = admin.GetMeasures(ctx, AdminMeasuresOpts{Keys: []AdminMeasureKey{MeasureActiveUsers}, StartAt: end, EndAt: start})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	measures, err := admin.GetMeasures(ctx, AdminMeasuresOpts{
+		Keys:    []AdminMeasureKey{MeasureActiveUsers, MeasureTagUses, MeasureInstanceAccounts},
+		StartAt: start,
+		EndAt:   end,
+		TagID:   "5",
+		Domain:  "example.com",
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(measures) != 3 {
+		t.Fatalf("result should be three: %d", len(measures))
+	}
+	if measures[0].Key != MeasureActiveUsers || measures[0].Total != 2 || measures[0].PreviousTotal != 1 {
+		t.Fatalf("want %q with total 2 but %v", MeasureActiveUsers, measures[0])
+	}
+	if len(measures[0].Data) != 2 || measures[0].Data[1].Value != 2 || !measures[0].Data[1].Date.Equal(end) {
+		t.Fatalf("want two days but %v", measures[0].Data)
+	}
+	if measures[2].Total != 10 {
+		t.Fatalf("want %d but %d", 10, measures[2].Total)
+	}
+}
+
+func TestAdminGetDimensions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/admin/dimensions" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		r.ParseForm()
+		if r.PostFormValue("limit") != "2" || r.PostFormValue("instance_languages[domain]") != "example.com" {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, `[{"key": "languages", "data": [{"key": "en", "human_key": "English", "value": "10"}, {"key": "de", "human_key": "German", "value": "3"}]}, {"key": "space_usage", "data": [{"key": "media", "human_key": "Media storage", "value": "1024", "unit": "bytes", "human_value": "1 KB"}]}, {"key": "instance_languages", "data": []}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	start := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := client.Admin().GetDimensions(context.Background(), AdminDimensionsOpts{StartAt: start, EndAt: start})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	dimensions, err := client.Admin().GetDimensions(context.Background(), AdminDimensionsOpts{
+		Keys:    []AdminDimensionKey{DimensionLanguages, DimensionSpaceUsage, DimensionInstanceLanguages},
+		StartAt: start,
+		EndAt:   start.Add(24 * time.Hour),
+		Limit:   2,
+		Domain:  "example.com",
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(dimensions) != 3 {
+		t.Fatalf("result should be three: %d", len(dimensions))
+	}
+	if dimensions[0].Key != DimensionLanguages || len(dimensions[0].Data) != 2 {
+		t.Fatalf("want two languages but %v", dimensions[0])
+	}
+	if dimensions[0].Data[0].HumanKey != "English" || dimensions[0].Data[0].Value != 10 {
+		t.Fatalf("want %q with 10 but %v", "English", dimensions[0].Data[0])
+	}
+	if dimensions[1].Data[0].Unit != "bytes" || dimensions[1].Data[0].Value != 1024 {
+		t.Fatalf("want 1024 bytes but %v", dimensions[1].Data[0])
+	}
+}
+
+func TestAdminGetRetention(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/admin/retention" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		if r.FormValue("frequency") != "month" {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, `[{"period": "2022-09-01T00:00:00.000+00:00", "frequency": "month", "data": [{"date": "2022-09-01T00:00:00.000+00:00", "rate": 1.0, "value": "2"}, {"date": "2022-10-01T00:00:00.000+00:00", "rate": 0.5, "value": "1"}]}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	start := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 2, 0)
+
+	_, err := client.Admin().GetRetention(context.Background(), start, end, RetentionDay)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	cohorts, err := client.Admin().GetRetention(context.Background(), start, end, RetentionMonth)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(cohorts) != 1 {
+		t.Fatalf("result should be one: %d", len(cohorts))
+	}
+	if !cohorts[0].Period.Equal(start) || cohorts[0].Frequency != RetentionMonth {
+		t.Fatalf("want cohort of %v but %v", start, cohorts[0])
+	}
+	if len(cohorts[0].Data) != 2 || cohorts[0].Data[1].Rate != 0.5 || cohorts[0].Data[1].Value != 1 {
+		t.Fatalf("want two periods but %v", cohorts[0].Data)
+	}
+}
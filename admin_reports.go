@@ -0,0 +1,108 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// AdminReport holds information for a report as seen by moderators.
+type AdminReport struct {
+	ID                   ID             `json:"id"`
+	ActionTaken          bool           `json:"action_taken"`
+	ActionTakenAt        time.Time      `json:"action_taken_at"`
+	Category             string         `json:"category"`
+	Comment              string         `json:"comment"`
+	Forwarded            bool           `json:"forwarded"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	Account              *AdminAccount  `json:"account"`
+	TargetAccount        *AdminAccount  `json:"target_account"`
+	AssignedAccount      *AdminAccount  `json:"assigned_account"`
+	ActionTakenByAccount *AdminAccount  `json:"action_taken_by_account"`
+	Statuses             []*Status      `json:"statuses"`
+	Rules                []InstanceRule `json:"rules"`
+}
+
+// AdminReportsOpts holds the filters for AdminClient.GetReports.
+type AdminReportsOpts struct {
+	Resolved        bool // List resolved reports instead of unresolved ones.
+	AccountID       ID   // Only list reports filed by this account.
+	TargetAccountID ID   // Only list reports against this account.
+
+	*Pagination
+}
+
+func (a AdminReportsOpts) params() url.Values {
+	params := &url.Values{}
+
+	stradd, badd := addParamFuncs(params)
+	badd("resolved", a.Resolved)
+	stradd("account_id", string(a.AccountID))
+	stradd("target_account_id", string(a.TargetAccountID))
+
+	return *params
+}
+
+// GetReports returns the reports matching opts.
+func (a *AdminClient) GetReports(ctx context.Context, opts AdminReportsOpts) ([]*AdminReport, error) {
+	var reports []*AdminReport
+	err := a.client.doAPI(ctx, http.MethodGet, "/api/v1/admin/reports", opts.params(), &reports, opts.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// GetReport returns a report, including the reported statuses and the
+// rules they violate.
+func (a *AdminClient) GetReport(ctx context.Context, id ID) (*AdminReport, error) {
+	return a.reportAction(ctx, http.MethodGet, id, "", nil)
+}
+
+// UpdateReport changes the category of a report and the rules it's about.
+func (a *AdminClient) UpdateReport(ctx context.Context, id ID, category string, ruleIDs []ID) (*AdminReport, error) {
+	params := url.Values{}
+	stradd := addParamString(&params)
+	stradd("category", category)
+	for _, id := range ruleIDs {
+		params.Add("rule_ids[]", string(id))
+	}
+	return a.reportAction(ctx, http.MethodPut, id, "", params)
+}
+
+func (a *AdminClient) reportAction(ctx context.Context, method string, id ID, action string, params url.Values) (*AdminReport, error) {
+	uri := fmt.Sprintf("/api/v1/admin/reports/%s", url.PathEscape(string(id)))
+	if action != "" {
+		uri += "/" + action
+	}
+
+	var report AdminReport
+	err := a.client.doAPI(ctx, method, uri, params, &report, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// AssignReportToSelf assigns a report to the current account.
+func (a *AdminClient) AssignReportToSelf(ctx context.Context, id ID) (*AdminReport, error) {
+	return a.reportAction(ctx, http.MethodPost, id, "assign_to_self", nil)
+}
+
+// UnassignReport unassigns a report.
+func (a *AdminClient) UnassignReport(ctx context.Context, id ID) (*AdminReport, error) {
+	return a.reportAction(ctx, http.MethodPost, id, "unassign", nil)
+}
+
+// ResolveReport marks a report as resolved without taking action.
+func (a *AdminClient) ResolveReport(ctx context.Context, id ID) (*AdminReport, error) {
+	return a.reportAction(ctx, http.MethodPost, id, "resolve", nil)
+}
+
+// ReopenReport reopens a resolved report.
+func (a *AdminClient) ReopenReport(ctx context.Context, id ID) (*AdminReport, error) {
+	return a.reportAction(ctx, http.MethodPost, id, "reopen", nil)
+}
@@ -0,0 +1,149 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminGetReports(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/admin/reports":
+			q := r.URL.Query()
+			if q.Get("resolved") != "true" || q.Get("target_account_id") != "2" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			if _, ok := q["account_id"]; ok {
+				http.Error(w, "account_id should not be set", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `[{"id": "1", "action_taken": true, "category": "spam", "account": {"id": "1", "username": "foo"}, "target_account": {"id": "2", "username": "bar"}, "assigned_account": null, "statuses": [], "rules": []}]`)
+		case "/api/v1/admin/reports/1":
+			fmt.Fprintln(w, `{"id": "1", "category": "violation", "comment": "rude", "assigned_account": {"id": "3", "username": "mod"}, "statuses": [{"id": "4", "content": "rude"}], "rules": [{"id": "2", "text": "Be nice"}]}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	reports, err := client.Admin().GetReports(context.Background(), AdminReportsOpts{Resolved: true, TargetAccountID: "2"})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("result should be one: %d", len(reports))
+	}
+	if reports[0].TargetAccount.Username != "bar" {
+		t.Fatalf("want %q but %q", "bar", reports[0].TargetAccount.Username)
+	}
+	if reports[0].AssignedAccount != nil {
+		t.Fatalf("want nil but %v", reports[0].AssignedAccount)
+	}
+
+	_, err = client.Admin().GetReport(context.Background(), "2")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	report, err := client.Admin().GetReport(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if report.AssignedAccount.Username != "mod" {
+		t.Fatalf("want %q but %q", "mod", report.AssignedAccount.Username)
+	}
+	if len(report.Statuses) != 1 || report.Statuses[0].ID != "4" {
+		t.Fatalf("want one status but %v", report.Statuses)
+	}
+	if len(report.Rules) != 1 || report.Rules[0].Text != "Be nice" {
+		t.Fatalf("want one rule but %v", report.Rules)
+	}
+}
+
+func TestAdminReportTriage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /api/v1/admin/reports/1":
+			r.ParseForm()
+			if r.PostFormValue("category") != "violation" || len(r.PostForm["rule_ids[]"]) != 2 {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `{"id": "1", "category": "violation", "rules": [{"id": "1"}, {"id": "2"}]}`)
+		case "POST /api/v1/admin/reports/1/assign_to_self":
+			fmt.Fprintln(w, `{"id": "1", "assigned_account": {"id": "3"}}`)
+		case "POST /api/v1/admin/reports/1/unassign":
+			fmt.Fprintln(w, `{"id": "1", "assigned_account": null}`)
+		case "POST /api/v1/admin/reports/1/resolve":
+			fmt.Fprintln(w, `{"id": "1", "action_taken": true}`)
+		case "POST /api/v1/admin/reports/1/reopen":
+			fmt.Fprintln(w, `{"id": "1", "action_taken": false}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.Admin()
+	ctx := context.Background()
+
+	_, err := admin.UpdateReport(ctx, "1", ReportCategorySpam, nil)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	report, err := admin.UpdateReport(ctx, "1", ReportCategoryViolation, []ID{"1", "2"})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(report.Rules) != 2 {
+		t.Fatalf("result should be two: %d", len(report.Rules))
+	}
+
+	report, err = admin.AssignReportToSelf(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if report.AssignedAccount == nil || report.AssignedAccount.ID != "3" {
+		t.Fatalf("want assigned account %q but %v", "3", report.AssignedAccount)
+	}
+	report, err = admin.UnassignReport(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if report.AssignedAccount != nil {
+		t.Fatalf("want nil but %v", report.AssignedAccount)
+	}
+	report, err = admin.ResolveReport(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if !report.ActionTaken {
+		t.Fatalf("want %v but %v", true, report.ActionTaken)
+	}
+	report, err = admin.ReopenReport(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if report.ActionTaken {
+		t.Fatalf("want %v but %v", false, report.ActionTaken)
+	}
+	_, err = admin.ResolveReport(ctx, "2")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+}
@@ -0,0 +1,189 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminGetAccounts(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/admin/accounts" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("origin") != "local" || q.Get("status") != "pending" || q.Get("role_ids[]") != "3" || q.Get("limit") != "2" {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		if _, ok := q["username"]; ok {
+			http.Error(w, "username should not be set", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Link", `<http://example.com?max_id=100>; rel="next"`)
+		fmt.Fprintln(w, `[{"id": "1", "username": "foo", "email": "foo@example.com", "ip": "127.0.0.1", "ips": [{"ip": "127.0.0.1", "used_at": "2022-09-08T22:48:07.985Z"}], "role": {"id": "3", "name": "Owner", "color": "", "permissions": "1048575", "highlighted": true}, "confirmed": true, "approved": false, "account": {"id": "1", "username": "foo"}}, {"id": "2", "username": "bar", "role": "moderator"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	pg := &Pagination{Limit: 2}
+	accounts, err := client.Admin().GetAccounts(context.Background(), AdminAccountsOpts{
+		Origin:     AdminOriginLocal,
+		Status:     AdminStatusPending,
+		RoleIDs:    []ID{"3"},
+		Pagination: pg,
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(accounts) != 2 {
+		t.Fatalf("result should be two: %d", len(accounts))
+	}
+	if accounts[0].Email != "foo@example.com" {
+		t.Fatalf("want %q but %q", "foo@example.com", accounts[0].Email)
+	}
+	if len(accounts[0].IPs) != 1 || accounts[0].IPs[0].UsedAt.IsZero() {
+		t.Fatalf("want one IP but %v", accounts[0].IPs)
+	}
+	if accounts[0].Role.Name != "Owner" || accounts[0].Role.Permissions != 1048575 {
+		t.Fatalf("want role %q but %v", "Owner", accounts[0].Role)
+	}
+	if accounts[0].Account == nil || accounts[0].Account.Username != "foo" {
+		t.Fatalf("want account %q but %v", "foo", accounts[0].Account)
+	}
+	if accounts[1].Role.Name != "moderator" {
+		t.Fatalf("want %q but %q", "moderator", accounts[1].Role.Name)
+	}
+	if pg.MaxID != "100" {
+		t.Fatalf("want %q but %q", "100", pg.MaxID)
+	}
+}
+
+func TestAdminAccountModeration(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/admin/accounts/1":
+			fmt.Fprintln(w, `{"id": "1", "username": "foo"}`)
+		case "POST /api/v1/admin/accounts/1/approve":
+			fmt.Fprintln(w, `{"id": "1", "approved": true}`)
+		case "POST /api/v1/admin/accounts/1/reject":
+			fmt.Fprintln(w, `{"id": "1", "approved": false}`)
+		case "POST /api/v1/admin/accounts/1/enable":
+			fmt.Fprintln(w, `{"id": "1", "disabled": false}`)
+		case "POST /api/v1/admin/accounts/1/unsilence":
+			fmt.Fprintln(w, `{"id": "1", "silenced": false}`)
+		case "POST /api/v1/admin/accounts/1/unsuspend":
+			fmt.Fprintln(w, `{"id": "1", "suspended": false}`)
+		case "POST /api/v1/admin/accounts/1/unsensitive":
+			fmt.Fprintln(w, `{"id": "1", "sensitized": false}`)
+		case "DELETE /api/v1/admin/accounts/1":
+			fmt.Fprintln(w, `{"id": "1", "suspended": true}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.Admin()
+	ctx := context.Background()
+
+	_, err := admin.GetAccount(ctx, "2")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	account, err := admin.GetAccount(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if account.Username != "foo" {
+		t.Fatalf("want %q but %q", "foo", account.Username)
+	}
+	account, err = admin.ApproveAccount(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if !account.Approved {
+		t.Fatalf("want %v but %v", true, account.Approved)
+	}
+	account, err = admin.DeleteAccount(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if !account.Suspended {
+		t.Fatalf("want %v but %v", true, account.Suspended)
+	}
+
+	for _, f := range []func(context.Context, ID) (*AdminAccount, error){
+		admin.RejectAccount,
+		admin.EnableAccount,
+		admin.UnsilenceAccount,
+		admin.UnsuspendAccount,
+		admin.UnsensitiveAccount,
+	} {
+		account, err := f(ctx, "1")
+		if err != nil {
+			t.Fatalf("should not be fail: %v", err)
+		}
+		if account.ID != "1" {
+			t.Fatalf("want %q but %q", "1", account.ID)
+		}
+		_, err = f(ctx, "2")
+		if err == nil {
+			t.Fatalf("should be fail: %v", err)
+		}
+	}
+}
+
+func TestAdminAccountAction(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/admin/accounts/1/action" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		r.ParseForm()
+		if r.PostFormValue("type") != "silence" || r.PostFormValue("report_id") != "5" || r.PostFormValue("warning_preset_id") != "2" || r.PostFormValue("send_email_notification") != "false" {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		if _, ok := r.PostForm["text"]; ok {
+			http.Error(w, "text should not be set", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	err := client.Admin().AccountAction(context.Background(), "1", AdminAccountAction{Type: AdminActionSuspend})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	err = client.Admin().AccountAction(context.Background(), "1", AdminAccountAction{
+		Type:                AdminActionSilence,
+		ReportID:            "5",
+		WarningPresetID:     "2",
+		NoEmailNotification: true,
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// AdminTrendsLink holds information for a trending link as seen by moderators.
+type AdminTrendsLink struct {
+	ID ID `json:"id"`
+	TrendsLink
+	RequiresReview bool `json:"requires_review"`
+}
+
+// AdminTag holds information for a tag as seen by moderators.
+type AdminTag struct {
+	Tag
+	Trendable      bool `json:"trendable"`
+	Usable         bool `json:"usable"`
+	Listable       bool `json:"listable"`
+	RequiresReview bool `json:"requires_review"`
+}
+
+// GetTrendingLinks returns the trending links, including the ones that
+// haven't been reviewed yet.
+func (a *AdminClient) GetTrendingLinks(ctx context.Context) ([]*AdminTrendsLink, error) {
+	var links []*AdminTrendsLink
+	err := a.client.doAPI(ctx, http.MethodGet, "/api/v1/admin/trends/links", nil, &links, nil)
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// GetTrendingStatuses returns the trending statuses, including the ones that
+// haven't been reviewed yet.
+func (a *AdminClient) GetTrendingStatuses(ctx context.Context) ([]*Status, error) {
+	var statuses []*Status
+	err := a.client.doAPI(ctx, http.MethodGet, "/api/v1/admin/trends/statuses", nil, &statuses, nil)
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// GetTrendingTags returns the trending tags, including the ones that
+// haven't been reviewed yet.
+func (a *AdminClient) GetTrendingTags(ctx context.Context) ([]*AdminTag, error) {
+	var tags []*AdminTag
+	err := a.client.doAPI(ctx, http.MethodGet, "/api/v1/admin/trends/tags", nil, &tags, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (a *AdminClient) reviewTrend(ctx context.Context, kind string, id ID, action string, res interface{}) error {
+	return a.client.doAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/admin/trends/%s/%s/%s", kind, url.PathEscape(string(id)), action), nil, res, nil)
+}
+
+// ApproveTrendingLink allows a link to be shown in trends.
+func (a *AdminClient) ApproveTrendingLink(ctx context.Context, id ID) (*AdminTrendsLink, error) {
+	var link AdminTrendsLink
+	if err := a.reviewTrend(ctx, "links", id, "approve", &link); err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// RejectTrendingLink keeps a link out of trends.
+func (a *AdminClient) RejectTrendingLink(ctx context.Context, id ID) (*AdminTrendsLink, error) {
+	var link AdminTrendsLink
+	if err := a.reviewTrend(ctx, "links", id, "reject", &link); err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// ApproveTrendingStatus allows a status to be shown in trends.
+func (a *AdminClient) ApproveTrendingStatus(ctx context.Context, id ID) (*Status, error) {
+	var status Status
+	if err := a.reviewTrend(ctx, "statuses", id, "approve", &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// RejectTrendingStatus keeps a status out of trends.
+func (a *AdminClient) RejectTrendingStatus(ctx context.Context, id ID) (*Status, error) {
+	var status Status
+	if err := a.reviewTrend(ctx, "statuses", id, "reject", &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ApproveTrendingTag allows a tag to be shown in trends.
+func (a *AdminClient) ApproveTrendingTag(ctx context.Context, id ID) (*AdminTag, error) {
+	var tag AdminTag
+	if err := a.reviewTrend(ctx, "tags", id, "approve", &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// RejectTrendingTag keeps a tag out of trends.
+func (a *AdminClient) RejectTrendingTag(ctx context.Context, id ID) (*AdminTag, error) {
+	var tag AdminTag
+	if err := a.reviewTrend(ctx, "tags", id, "reject", &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
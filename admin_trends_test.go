@@ -0,0 +1,132 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminGetTrends(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/admin/trends/links":
+			fmt.Fprintln(w, `[{"id": "1", "url": "https://example.com/", "title": "Example", "type": "link", "history": [{"day": "1661817600", "accounts": "7", "uses": "7"}], "requires_review": true}]`)
+		case "/api/v1/admin/trends/statuses":
+			fmt.Fprintln(w, `[{"id": "2", "content": "trending"}]`)
+		case "/api/v1/admin/trends/tags":
+			fmt.Fprintln(w, `[{"id": "3", "name": "caturday", "url": "https://example.com/tags/caturday", "history": [], "trendable": false, "usable": true, "requires_review": true}]`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.Admin()
+	ctx := context.Background()
+
+	links, err := admin.GetTrendingLinks(ctx)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(links) != 1 {
+		t.Fatalf("result should be one: %d", len(links))
+	}
+	if links[0].ID != "1" || links[0].Title != "Example" || !links[0].RequiresReview || len(links[0].History) != 1 {
+		t.Fatalf("want link %q but %v", "1", links[0])
+	}
+
+	statuses, err := admin.GetTrendingStatuses(ctx)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].ID != "2" {
+		t.Fatalf("want status %q but %v", "2", statuses)
+	}
+
+	tags, err := admin.GetTrendingTags(ctx)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(tags) != 1 {
+		t.Fatalf("result should be one: %d", len(tags))
+	}
+	if tags[0].ID != "3" || tags[0].Name != "caturday" || tags[0].Trendable || !tags[0].Usable || !tags[0].RequiresReview {
+		t.Fatalf("want tag %q but %v", "caturday", tags[0])
+	}
+}
+
+func TestAdminReviewTrends(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/admin/trends/links/1/approve", "/api/v1/admin/trends/links/1/reject":
+			fmt.Fprintln(w, `{"id": "1", "url": "https://example.com/", "requires_review": false}`)
+		case "/api/v1/admin/trends/statuses/2/approve", "/api/v1/admin/trends/statuses/2/reject":
+			fmt.Fprintln(w, `{"id": "2"}`)
+		case "/api/v1/admin/trends/tags/3/approve":
+			fmt.Fprintln(w, `{"id": "3", "name": "caturday", "trendable": true}`)
+		case "/api/v1/admin/trends/tags/3/reject":
+			fmt.Fprintln(w, `{"id": "3", "name": "caturday", "trendable": false}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.Admin()
+	ctx := context.Background()
+
+	for _, f := range []func(context.Context, ID) (*AdminTrendsLink, error){admin.ApproveTrendingLink, admin.RejectTrendingLink} {
+		link, err := f(ctx, "1")
+		if err != nil {
+			t.Fatalf("should not be fail: %v", err)
+		}
+		if link.ID != "1" || link.URL != "https://example.com/" {
+			t.Fatalf("want link %q but %v", "1", link)
+		}
+		if _, err := f(ctx, "2"); err == nil {
+			t.Fatalf("should be fail: %v", err)
+		}
+	}
+	for _, f := range []func(context.Context, ID) (*Status, error){admin.ApproveTrendingStatus, admin.RejectTrendingStatus} {
+		status, err := f(ctx, "2")
+		if err != nil {
+			t.Fatalf("should not be fail: %v", err)
+		}
+		if status.ID != "2" {
+			t.Fatalf("want %q but %q", "2", status.ID)
+		}
+	}
+
+	tag, err := admin.ApproveTrendingTag(ctx, "3")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if !tag.Trendable {
+		t.Fatalf("want %v but %v", true, tag.Trendable)
+	}
+	tag, err = admin.RejectTrendingTag(ctx, "3")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if tag.Trendable {
+		t.Fatalf("want %v but %v", false, tag.Trendable)
+	}
+}
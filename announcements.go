@@ -0,0 +1,77 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Announcement holds information for an announcement set by administrators.
+// StartsAt and EndsAt are nil if the announcement has no time range.
+type Announcement struct {
+	ID          ID                     `json:"id"`
+	Content     string                 `json:"content"`
+	StartsAt    *time.Time             `json:"starts_at"`
+	EndsAt      *time.Time             `json:"ends_at"`
+	AllDay      bool                   `json:"all_day"`
+	PublishedAt time.Time              `json:"published_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+	Read        bool                   `json:"read"`
+	Mentions    []Mention              `json:"mentions"`
+	Statuses    []AnnouncementStatus   `json:"statuses"`
+	Tags        []Tag                  `json:"tags"`
+	Emojis      []Emoji                `json:"emojis"`
+	Reactions   []AnnouncementReaction `json:"reactions"`
+}
+
+// AnnouncementStatus holds a status linked in an announcement.
+type AnnouncementStatus struct {
+	ID  ID     `json:"id"`
+	URL string `json:"url"`
+}
+
+// AnnouncementReaction holds an emoji reaction to an announcement. URL and
+// StaticURL are only set for custom emoji. AnnouncementID is only set when
+// it's received from streaming.
+type AnnouncementReaction struct {
+	Name           string `json:"name"`
+	Count          int64  `json:"count"`
+	Me             bool   `json:"me"`
+	URL            string `json:"url"`
+	StaticURL      string `json:"static_url"`
+	AnnouncementID ID     `json:"announcement_id"`
+}
+
+// GetAnnouncements returns the currently active announcements.
+// If withDismissed is true, announcements dismissed by the user are included.
+func (c *Client) GetAnnouncements(ctx context.Context, withDismissed bool) ([]*Announcement, error) {
+	params := url.Values{}
+	if withDismissed {
+		params.Set("with_dismissed", "true")
+	}
+
+	var announcements []*Announcement
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/announcements", params, &announcements, nil)
+	if err != nil {
+		return nil, err
+	}
+	return announcements, nil
+}
+
+// DismissAnnouncement marks an announcement as read.
+func (c *Client) DismissAnnouncement(ctx context.Context, id ID) error {
+	return c.doAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/announcements/%s/dismiss", url.PathEscape(id)), nil, nil, nil)
+}
+
+// AddAnnouncementReaction reacts to an announcement with a unicode emoji
+// or the shortcode of a custom emoji.
+func (c *Client) AddAnnouncementReaction(ctx context.Context, id ID, name string) error {
+	return c.doAPI(ctx, http.MethodPut, fmt.Sprintf("/api/v1/announcements/%s/reactions/%s", url.PathEscape(id), name), nil, nil, nil)
+}
+
+// RemoveAnnouncementReaction removes a reaction from an announcement.
+func (c *Client) RemoveAnnouncementReaction(ctx context.Context, id ID, name string) error {
+	return c.doAPI(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/announcements/%s/reactions/%s", url.PathEscape(id), name), nil, nil, nil)
+}
@@ -0,0 +1,139 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetAnnouncements(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/announcements" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		if r.FormValue("with_dismissed") == "true" {
+			fmt.Fprintln(w, `[{"id": "8", "content": "<p>Old</p>", "starts_at": null, "ends_at": null, "read": true}, {"id": "9", "content": "<p>New</p>"}]`)
+			return
+		}
+		fmt.Fprintln(w, `[{"id": "9", "content": "<p>New</p>", "starts_at": "2020-03-04T00:00:00Z", "ends_at": "2020-03-05T00:00:00Z", "all_day": true, "published_at": "2020-03-03T19:28:42Z", "read": false, "mentions": [{"id": "1", "username": "foo", "acct": "foo"}], "statuses": [{"id": "2", "url": "https://example.com/@foo/2"}], "tags": [{"name": "news", "url": "https://example.com/tags/news"}], "emojis": [], "reactions": [{"name": "bongoCat", "count": 9, "me": true, "url": "https://example.com/bongo.png", "static_url": "https://example.com/bongo_static.png"}]}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	as, err := client.GetAnnouncements(context.Background(), false)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(as) != 1 {
+		t.Fatalf("result should be one: %d", len(as))
+	}
+	a := as[0]
+	if a.ID != "9" {
+		t.Fatalf("want %q but %q", "9", a.ID)
+	}
+	if !a.AllDay {
+		t.Fatal("want all day")
+	}
+	if a.StartsAt == nil || a.EndsAt == nil {
+		t.Fatalf("want a time range but %v %v", a.StartsAt, a.EndsAt)
+	}
+	if a.EndsAt.Sub(*a.StartsAt).Hours() != 24 {
+		t.Fatalf("want %v but %v", 24, a.EndsAt.Sub(*a.StartsAt).Hours())
+	}
+	if len(a.Mentions) != 1 || a.Mentions[0].Username != "foo" {
+		t.Fatalf("want one mention but %v", a.Mentions)
+	}
+	if len(a.Statuses) != 1 || a.Statuses[0].ID != "2" {
+		t.Fatalf("want one status but %v", a.Statuses)
+	}
+	if len(a.Tags) != 1 || a.Tags[0].Name != "news" {
+		t.Fatalf("want one tag but %v", a.Tags)
+	}
+	if len(a.Reactions) != 1 || !a.Reactions[0].Me || a.Reactions[0].Count != 9 {
+		t.Fatalf("want one reaction but %v", a.Reactions)
+	}
+
+	as, err = client.GetAnnouncements(context.Background(), true)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(as) != 2 {
+		t.Fatalf("result should be two: %d", len(as))
+	}
+	if !as[0].Read {
+		t.Fatal("want dismissed announcement to be read")
+	}
+	if as[0].StartsAt != nil || as[0].EndsAt != nil {
+		t.Fatalf("want no time range but %v %v", as[0].StartsAt, as[0].EndsAt)
+	}
+}
+
+func TestDismissAnnouncement(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/announcements/8/dismiss" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	err := client.DismissAnnouncement(context.Background(), "7")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	err = client.DismissAnnouncement(context.Background(), "8")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+}
+
+func TestAnnouncementReactions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/announcements/8/reactions/bongoCat" && r.URL.Path != "/api/v1/announcements/8/reactions/🎉" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		if r.Method != http.MethodPut && r.Method != http.MethodDelete {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	err := client.AddAnnouncementReaction(context.Background(), "8", "blobCat")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	err = client.AddAnnouncementReaction(context.Background(), "8", "bongoCat")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	err = client.RemoveAnnouncementReaction(context.Background(), "8", "bongoCat")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	err = client.AddAnnouncementReaction(context.Background(), "8", "🎉")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+}
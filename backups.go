@@ -0,0 +1,121 @@
+package masta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Convenience constants for Backup.State
+const (
+	BackupStatePending  = "pending"
+	BackupStateRunning  = "running"
+	BackupStateComplete = "complete"
+	BackupStateFailed   = "failed"
+	BackupStateInvalid  = "invalid"
+)
+
+// Backup holds a Pleroma backup of the current user's data. URL points to
+// the archive, which can only be downloaded once the backup is processed.
+// State and ProcessedNumber are only sent by Pleroma 2.5 and later.
+type Backup struct {
+	ContentType     string    `json:"content_type"`
+	FileSize        int64     `json:"file_size"`
+	InsertedAt      time.Time `json:"inserted_at"`
+	Processed       bool      `json:"processed"`
+	ProcessedNumber int64     `json:"processed_number"`
+	State           string    `json:"state"`
+	URL             string    `json:"url"`
+}
+
+// Failed reports whether the backup can't be completed.
+func (b *Backup) Failed() bool {
+	return b.State == BackupStateFailed || b.State == BackupStateInvalid
+}
+
+// PlGetBackups returns the backups of the current user.
+func (c *Client) PlGetBackups(ctx context.Context) ([]*Backup, error) {
+	var backups []*Backup
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/pleroma/backups", nil, &backups, nil)
+	if err != nil {
+		return nil, err
+	}
+	return backups, nil
+}
+
+// PlCreateBackup requests a backup of the current user's data, which is
+// processed in the background, and returns the backups of the current user.
+func (c *Client) PlCreateBackup(ctx context.Context) ([]*Backup, error) {
+	var backups []*Backup
+	err := c.doAPI(ctx, http.MethodPost, "/api/v1/pleroma/backups", nil, &backups, nil)
+	if err != nil {
+		return nil, err
+	}
+	return backups, nil
+}
+
+// PlCreateBackupWait requests a backup and polls every interval until its
+// archive is ready. It returns an error if the backup fails or ctx is done.
+func (c *Client) PlCreateBackupWait(ctx context.Context, interval time.Duration) (*Backup, error) {
+	backups, err := c.PlCreateBackup(ctx)
+	if err != nil {
+		return nil, err
+	}
+	backup := latestBackup(backups)
+	if backup == nil {
+		return nil, errors.New("backup wasn't created")
+	}
+	return c.PlWaitBackup(ctx, backup, interval)
+}
+
+// PlWaitBackup polls every interval until the archive of a backup is ready,
+// and returns the processed backup. It returns an error if the backup fails
+// or ctx is done.
+func (c *Client) PlWaitBackup(ctx context.Context, backup *Backup, interval time.Duration) (*Backup, error) {
+	for {
+		if backup.Failed() {
+			return nil, fmt.Errorf("backup from %s %s", backup.InsertedAt.Format(time.RFC3339), backup.State)
+		}
+		if backup.Processed {
+			return backup, nil
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		backups, err := c.PlGetBackups(ctx)
+		if err != nil {
+			return nil, err
+		}
+		backup = findBackup(backups, backup.InsertedAt)
+		if backup == nil {
+			return nil, errors.New("backup was deleted")
+		}
+	}
+}
+
+// latestBackup returns the most recently created backup. Backups have no
+// ID, so they are told apart by their creation time.
+func latestBackup(backups []*Backup) *Backup {
+	var latest *Backup
+	for _, b := range backups {
+		if latest == nil || b.InsertedAt.After(latest.InsertedAt) {
+			latest = b
+		}
+	}
+	return latest
+}
+
+func findBackup(backups []*Backup, insertedAt time.Time) *Backup {
+	for _, b := range backups {
+		if b.InsertedAt.Equal(insertedAt) {
+			return b
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPlBackups(t *testing.T) {
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/pleroma/backups" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			fmt.Fprintln(w, `[{"content_type": "application/zip", "inserted_at": "2020-09-08T16:42:07.000Z", "processed": true, "state": "complete", "url": "https://example.com/old.zip"}, {"content_type": "application/zip", "inserted_at": "2020-09-10T16:42:07.000Z", "processed": false, "state": "pending", "url": "https://example.com/new.zip"}]`)
+		case http.MethodGet:
+			polls++
+			if polls < 3 {
+				fmt.Fprintln(w, `[{"inserted_at": "2020-09-08T16:42:07.000Z", "processed": true, "state": "complete"}, {"inserted_at": "2020-09-10T16:42:07.000Z", "processed": false, "state": "running", "processed_number": 10}]`)
+				return
+			}
+			fmt.Fprintln(w, `[{"inserted_at": "2020-09-08T16:42:07.000Z", "processed": true, "state": "complete"}, {"inserted_at": "2020-09-10T16:42:07.000Z", "processed": true, "state": "complete", "file_size": 1024, "url": "https://example.com/new.zip"}]`)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	backup, err := client.PlCreateBackupWait(context.Background(), time.Millisecond)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if polls != 3 {
+		t.Fatalf("want 3 polls but %d", polls)
+	}
+	if !backup.Processed || backup.URL != "https://example.com/new.zip" || backup.FileSize != 1024 {
+		t.Fatalf("want new.zip but %#v", backup)
+	}
+
+	backups, err := client.PlGetBackups(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("result should be two: %d", len(backups))
+	}
+}
+
+func TestPlWaitBackupFailed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"inserted_at": "2020-09-10T16:42:07.000Z", "processed": false, "state": "failed"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	insertedAt := time.Date(2020, 9, 10, 16, 42, 7, 0, time.UTC)
+	_, err := client.PlWaitBackup(context.Background(), &Backup{InsertedAt: insertedAt, State: BackupStatePending}, time.Millisecond)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+
+	_, err = client.PlWaitBackup(context.Background(), &Backup{InsertedAt: insertedAt.Add(time.Hour)}, time.Millisecond)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.PlWaitBackup(ctx, &Backup{InsertedAt: insertedAt}, time.Hour)
+	if err != context.Canceled {
+		t.Fatalf("want %v but %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,234 @@
+package masta
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Convenience constants for Capabilities.Software
+const (
+	SoftwareUnknown    = ""
+	SoftwareMastodon   = "mastodon"
+	SoftwarePleroma    = "pleroma"
+	SoftwareAkkoma     = "akkoma"
+	SoftwareGoToSocial = "gotosocial"
+	SoftwareMisskey    = "misskey"
+)
+
+// Capabilities describes the software a server runs and which API features
+// it's known to support. It's a best guess derived from the instance and
+// NodeInfo documents, so treat it as a hint rather than a guarantee.
+type Capabilities struct {
+	// Software is one of the Software constants. Forks of Misskey
+	// (Calckey, Firefish, Sharkey, etc.) are all reported as SoftwareMisskey.
+	Software string
+	// Name is the software name exactly as the server reported it.
+	Name    string
+	Version string
+	// APIVersion is the Mastodon API version the server claims to be
+	// compatible with, i.e. the part before "(compatible; ...)".
+	APIVersion string
+
+	EditStatus      bool // Statuses can be edited with UpdateStatus.
+	MediaAttributes bool // Edits honour Toot.EditMediaAttributes.
+	EmojiReactions  bool
+	Chats           bool
+	QuotePosts      bool
+	InstanceV2      bool // /api/v2/instance is available.
+
+	// ContentTypes are the values accepted by Toot.ContentType, if any.
+	ContentTypes []string
+
+	// Features is the raw list of feature flags advertised by Pleroma
+	// and Akkoma servers.
+	Features []string
+}
+
+// HasFeature reports whether the server advertised a Pleroma-style feature flag.
+func (c *Capabilities) HasFeature(feature string) bool {
+	for _, f := range c.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsContentType reports whether the server accepts contentType in Toot.ContentType.
+func (c *Capabilities) SupportsContentType(contentType string) bool {
+	for _, ct := range c.ContentTypes {
+		if ct == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// Capabilities detects the software the server is running and what it supports.
+// The result is cached on the client, subsequent calls won't make any requests
+// until ForgetCapabilities is called. Each call returns its own copy, which
+// the caller may modify. Concurrent calls before the result is cached may
+// each detect the capabilities.
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	c.capsMu.Lock()
+	cached := c.caps
+	c.capsMu.Unlock()
+	if cached != nil {
+		return cached.clone(), nil
+	}
+
+	caps, err := c.detectCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.capsMu.Lock()
+	c.caps = caps
+	c.capsMu.Unlock()
+	return caps.clone(), nil
+}
+
+func (c *Capabilities) clone() *Capabilities {
+	caps := *c
+	caps.ContentTypes = append([]string(nil), c.ContentTypes...)
+	caps.Features = append([]string(nil), c.Features...)
+	return &caps
+}
+
+// ForgetCapabilities clears the result cached by Capabilities, i.e. after
+// the server has been upgraded.
+func (c *Client) ForgetCapabilities() {
+	c.capsMu.Lock()
+	c.caps = nil
+	c.capsMu.Unlock()
+}
+
+var compatibleVersionRe = regexp.MustCompile(`^\s*(\S+)\s*\(compatible;\s*([^\s)]+)\s*([^\s)]*)\s*\)`)
+
+func (c *Client) detectCapabilities(ctx context.Context) (*Capabilities, error) {
+	caps := &Capabilities{}
+
+	// Each source is optional on its own, but we need at least one of them.
+	instance, ierr := c.GetInstance(ctx)
+
+	var v2 InstanceV2
+	if err := c.doAPI(ctx, http.MethodGet, "/api/v2/instance", nil, &v2, nil); err == nil {
+		caps.InstanceV2 = true
+	}
+
+	ni, nerr := getNodeInfo(ctx, &c.Client, c.UserAgent, c.Config.Server)
+
+	if ierr != nil && nerr != nil && !caps.InstanceV2 {
+		return nil, ierr
+	}
+
+	version := v2.Version
+	if instance != nil {
+		version = instance.Version
+		if instance.Pleroma != nil {
+			caps.Features = instance.Pleroma.Metadata.Features
+			caps.ContentTypes = instance.Pleroma.Metadata.PostFormats
+		}
+	}
+
+	caps.APIVersion = version
+	if m := compatibleVersionRe.FindStringSubmatch(version); m != nil {
+		caps.APIVersion = m[1]
+		caps.Name = m[2]
+		caps.Version = m[3]
+	} else if fields := strings.Fields(version); len(fields) > 0 {
+		caps.APIVersion = fields[0]
+		caps.Version = fields[0]
+	}
+
+	if ni != nil && ni.Software.Name != "" {
+		caps.Name = ni.Software.Name
+		caps.Version = ni.Software.Version
+		if len(caps.Features) == 0 {
+			caps.Features = ni.Metadata.Features
+		}
+		if len(caps.ContentTypes) == 0 {
+			caps.ContentTypes = ni.Metadata.PostFormats
+		}
+	} else if caps.Name == "" {
+		if strings.Contains(version, "git-") {
+			caps.Name = SoftwareGoToSocial
+		} else if version != "" {
+			caps.Name = SoftwareMastodon
+		}
+	}
+
+	caps.Software = softwareFromName(caps.Name)
+	caps.detectFeatures()
+
+	return caps, nil
+}
+
+func softwareFromName(name string) string {
+	switch strings.ToLower(name) {
+	case "mastodon", "hometown", "glitch", "glitchsoc":
+		return SoftwareMastodon
+	case "pleroma":
+		return SoftwarePleroma
+	case "akkoma":
+		return SoftwareAkkoma
+	case "gotosocial":
+		return SoftwareGoToSocial
+	case "misskey", "calckey", "firefish", "sharkey", "foundkey", "iceshrimp", "catodon", "cherrypick":
+		return SoftwareMisskey
+	}
+	return SoftwareUnknown
+}
+
+func (c *Capabilities) detectFeatures() {
+	switch c.Software {
+	case SoftwareMastodon:
+		c.EditStatus = versionAtLeast(c.Version, 3, 5)
+		c.MediaAttributes = versionAtLeast(c.Version, 4, 0)
+		if len(c.ContentTypes) == 0 {
+			c.ContentTypes = []string{"text/plain"}
+			// glitch-soc accepts Markdown and HTML.
+			if strings.Contains(c.Version, "glitch") {
+				c.ContentTypes = append(c.ContentTypes, "text/markdown", "text/html")
+			}
+		}
+	case SoftwarePleroma, SoftwareAkkoma:
+		c.EditStatus = c.HasFeature("editing")
+		c.EmojiReactions = c.HasFeature("pleroma_emoji_reactions")
+		c.Chats = c.HasFeature("pleroma_chat_messages")
+		c.QuotePosts = c.HasFeature("quote_posting")
+	case SoftwareGoToSocial:
+		c.EditStatus = versionAtLeast(c.Version, 0, 18)
+		if len(c.ContentTypes) == 0 {
+			c.ContentTypes = []string{"text/plain", "text/markdown"}
+		}
+	case SoftwareMisskey:
+		c.EmojiReactions = true
+		c.QuotePosts = true
+	}
+}
+
+// versionAtLeast reports whether version is at least major.minor. Anything
+// after the first non-numeric character of a component is ignored.
+func versionAtLeast(version string, major, minor int) bool {
+	parts := strings.SplitN(version, ".", 3)
+	nums := make([]int, 2)
+	for i := 0; i < len(parts) && i < 2; i++ {
+		end := strings.IndexFunc(parts[i], func(r rune) bool { return r < '0' || r > '9' })
+		if end == -1 {
+			end = len(parts[i])
+		}
+		n, err := strconv.Atoi(parts[i][:end])
+		if err != nil {
+			return false
+		}
+		nums[i] = n
+	}
+
+	if nums[0] != major {
+		return nums[0] > major
+	}
+	return nums[1] >= minor
+}
@@ -0,0 +1,149 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCapabilitiesPleroma(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/instance":
+			fmt.Fprintln(w, `{"uri": "https://pl.example.com", "version": "2.7.2 (compatible; Pleroma 2.5.2)", "pleroma": {"metadata": {"features": ["pleroma_emoji_reactions", "pleroma_chat_messages", "editing"], "post_formats": ["text/plain", "text/html", "text/markdown"]}}}`)
+		case "/.well-known/nodeinfo":
+			fmt.Fprintf(w, `{"links": [{"rel": "http://nodeinfo.diaspora.software/ns/schema/2.0", "href": "%s/nodeinfo/2.0.json"}, {"rel": "http://nodeinfo.diaspora.software/ns/schema/2.1", "href": "%s/nodeinfo/2.1.json"}]}`, ts.URL, ts.URL)
+		case "/nodeinfo/2.1.json":
+			fmt.Fprintln(w, `{"software": {"name": "pleroma", "version": "2.5.2"}, "metadata": {"features": ["quote_posting"]}}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	caps, err := client.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if caps.Software != SoftwarePleroma {
+		t.Fatalf("want %q but %q", SoftwarePleroma, caps.Software)
+	}
+	if caps.Version != "2.5.2" {
+		t.Fatalf("want %q but %q", "2.5.2", caps.Version)
+	}
+	if caps.APIVersion != "2.7.2" {
+		t.Fatalf("want %q but %q", "2.7.2", caps.APIVersion)
+	}
+	if !caps.EditStatus || !caps.EmojiReactions || !caps.Chats {
+		t.Fatalf("want edits, reactions and chats but %+v", caps)
+	}
+	if caps.QuotePosts {
+		t.Fatal("instance features should take precedence over nodeinfo")
+	}
+	if caps.MediaAttributes {
+		t.Fatal("pleroma doesn't support media_attributes")
+	}
+	if caps.InstanceV2 {
+		t.Fatal("v2 instance should not be available")
+	}
+	if !caps.SupportsContentType("text/markdown") {
+		t.Fatalf("want markdown support but %v", caps.ContentTypes)
+	}
+
+	caps.Features[0] = "foo"
+	caps.Chats = false
+	cached, err := client.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if !cached.HasFeature("pleroma_emoji_reactions") || !cached.Chats {
+		t.Fatalf("changes to the result should not affect the cache but %+v", cached)
+	}
+}
+
+func TestCapabilitiesMastodon(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/api/v1/instance":
+			fmt.Fprintln(w, `{"uri": "mstdn.example.com", "version": "4.1.2"}`)
+		case "/api/v2/instance":
+			fmt.Fprintln(w, `{"domain": "mstdn.example.com", "version": "4.1.2"}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	caps, err := client.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if caps.Software != SoftwareMastodon {
+		t.Fatalf("want %q but %q", SoftwareMastodon, caps.Software)
+	}
+	if !caps.EditStatus || !caps.MediaAttributes || !caps.InstanceV2 {
+		t.Fatalf("want edits, media attributes and v2 instance but %+v", caps)
+	}
+	if caps.EmojiReactions || caps.Chats {
+		t.Fatalf("want no reactions or chats but %+v", caps)
+	}
+	if caps.SupportsContentType("text/markdown") {
+		t.Fatal("mastodon doesn't support markdown")
+	}
+
+	n := requests
+	if _, err := client.Capabilities(context.Background()); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if requests != n {
+		t.Fatalf("capabilities should be cached, made %d more requests", requests-n)
+	}
+
+	client.ForgetCapabilities()
+	if _, err := client.Capabilities(context.Background()); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if requests == n {
+		t.Fatal("capabilities should be detected again after ForgetCapabilities")
+	}
+}
+
+func TestCapabilitiesUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	_, err := client.Capabilities(context.Background())
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version      string
+		major, minor int
+		want         bool
+	}{
+		{"4.1.2", 4, 0, true},
+		{"3.4.9", 3, 5, false},
+		{"3.5.0rc1", 3, 5, true},
+		{"0.18.1 git-abc", 0, 18, true},
+		{"0.9.0", 0, 18, false},
+		{"", 0, 0, false},
+	}
+	for _, test := range tests {
+		if got := versionAtLeast(test.version, test.major, test.minor); got != test.want {
+			t.Errorf("versionAtLeast(%q, %d, %d): want %v but %v", test.version, test.major, test.minor, test.want, got)
+		}
+	}
+}
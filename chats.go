@@ -0,0 +1,115 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Chat holds information for a Pleroma chat with another account.
+type Chat struct {
+	ID          ID           `json:"id"`
+	Account     Account      `json:"account"`
+	Unread      int64        `json:"unread"`
+	LastMessage *ChatMessage `json:"last_message"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// ChatMessage holds information for a message in a Pleroma chat.
+type ChatMessage struct {
+	ID             ID          `json:"id"`
+	AccountID      ID          `json:"account_id"`
+	ChatID         ID          `json:"chat_id"`
+	Content        string      `json:"content"`
+	CreatedAt      time.Time   `json:"created_at"`
+	Emojis         []Emoji     `json:"emojis"`
+	Attachment     *Attachment `json:"attachment"`
+	Card           *Card       `json:"card"`
+	Unread         bool        `json:"unread"`
+	IdempotencyKey string      `json:"idempotency_key"`
+}
+
+// PlGetChats returns the chats of the current user, most recently updated
+// first.
+func (c *Client) PlGetChats(ctx context.Context, pg *Pagination) ([]*Chat, error) {
+	var chats []*Chat
+	err := c.doAPI(ctx, http.MethodGet, "/api/v2/pleroma/chats", nil, &chats, pg)
+	if err != nil {
+		return nil, err
+	}
+	return chats, nil
+}
+
+// PlGetChat returns a chat.
+func (c *Client) PlGetChat(ctx context.Context, id ID) (*Chat, error) {
+	var chat Chat
+	err := c.doAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v1/pleroma/chats/%s", url.PathEscape(string(id))), nil, &chat, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &chat, nil
+}
+
+// PlGetOrCreateChat returns the chat with an account, creating it if it
+// doesn't exist yet.
+func (c *Client) PlGetOrCreateChat(ctx context.Context, accountID ID) (*Chat, error) {
+	var chat Chat
+	err := c.doAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/pleroma/chats/by-account-id/%s", url.PathEscape(string(accountID))), nil, &chat, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &chat, nil
+}
+
+// PlGetChatMessages returns the messages in a chat, newest first.
+func (c *Client) PlGetChatMessages(ctx context.Context, chatID ID, pg *Pagination) ([]*ChatMessage, error) {
+	var messages []*ChatMessage
+	err := c.doAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v1/pleroma/chats/%s/messages", url.PathEscape(string(chatID))), nil, &messages, pg)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// PlPostChatMessage posts a message to a chat. Either content or mediaID,
+// the ID of an uploaded attachment, may be empty.
+func (c *Client) PlPostChatMessage(ctx context.Context, chatID ID, content string, mediaID ID) (*ChatMessage, error) {
+	params := url.Values{}
+	stradd := addParamString(&params)
+	stradd("content", content)
+	stradd("media_id", string(mediaID))
+
+	var message ChatMessage
+	err := c.doAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/pleroma/chats/%s/messages", url.PathEscape(string(chatID))), params, &message, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// PlDeleteChatMessage deletes a message from a chat, and returns it.
+func (c *Client) PlDeleteChatMessage(ctx context.Context, chatID, messageID ID) (*ChatMessage, error) {
+	var message ChatMessage
+	err := c.doAPI(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/pleroma/chats/%s/messages/%s", url.PathEscape(string(chatID)), url.PathEscape(string(messageID))), nil, &message, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// PlMarkChatRead marks the messages in a chat up to lastReadID as read.
+// If lastReadID is empty, all messages are marked as read.
+func (c *Client) PlMarkChatRead(ctx context.Context, chatID, lastReadID ID) (*Chat, error) {
+	params := url.Values{}
+	stradd := addParamString(&params)
+	stradd("last_read_id", string(lastReadID))
+
+	var chat Chat
+	err := c.doAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/pleroma/chats/%s/read", url.PathEscape(string(chatID))), params, &chat, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &chat, nil
+}
@@ -0,0 +1,173 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPlGetChats(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v2/pleroma/chats":
+			if r.URL.Query().Get("limit") != "1" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Link", `<http://example.com?max_id=1>; rel="next"`)
+			fmt.Fprintln(w, `[{"id": "1", "account": {"id": "2", "acct": "foo", "pleroma": {"accepts_chat_messages": true}}, "unread": 2, "last_message": {"id": "3", "account_id": "2", "chat_id": "1", "content": "hi", "attachment": null, "unread": true}, "updated_at": "2020-04-21T15:11:46.000Z"}]`)
+		case "GET /api/v1/pleroma/chats/1":
+			fmt.Fprintln(w, `{"id": "1", "account": {"id": "2"}, "unread": 0, "last_message": null}`)
+		case "POST /api/v1/pleroma/chats/by-account-id/2":
+			fmt.Fprintln(w, `{"id": "1", "account": {"id": "2"}, "unread": 0}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	pg := &Pagination{Limit: 1}
+	chats, err := client.PlGetChats(context.Background(), pg)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(chats) != 1 {
+		t.Fatalf("result should be one: %d", len(chats))
+	}
+	if chats[0].Account.Acct != "foo" || chats[0].Unread != 2 || chats[0].UpdatedAt.IsZero() {
+		t.Fatalf("want chat with %q but %v", "foo", chats[0])
+	}
+	if chats[0].LastMessage == nil || chats[0].LastMessage.Content != "hi" || !chats[0].LastMessage.Unread {
+		t.Fatalf("want last message %q but %v", "hi", chats[0].LastMessage)
+	}
+	if pg.MaxID != "1" {
+		t.Fatalf("want %q but %q", "1", pg.MaxID)
+	}
+
+	chat, err := client.PlGetChat(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if chat.LastMessage != nil {
+		t.Fatalf("want nil but %v", chat.LastMessage)
+	}
+	_, err = client.PlGetChat(context.Background(), "2")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+
+	chat, err = client.PlGetOrCreateChat(context.Background(), "2")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if chat.ID != "1" || chat.Account.ID != "2" {
+		t.Fatalf("want chat %q but %v", "1", chat)
+	}
+	_, err = client.PlGetOrCreateChat(context.Background(), "3")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+}
+
+func TestPlChatMessages(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/pleroma/chats/1/messages":
+			if r.URL.Query().Get("max_id") != "5" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `[{"id": "4", "chat_id": "1", "content": "hey", "emojis": [{"shortcode": "firefox"}], "attachment": {"id": "6", "type": "image"}}, {"id": "3", "chat_id": "1", "content": "hi"}]`)
+		case "POST /api/v1/pleroma/chats/1/messages":
+			if _, ok := r.PostForm["media_id"]; ok && r.PostFormValue("media_id") == "" {
+				http.Error(w, "media_id should not be empty", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintf(w, `{"id": "7", "chat_id": "1", "content": %q, "attachment": {"id": %q}}`, r.PostFormValue("content"), r.PostFormValue("media_id"))
+		case "DELETE /api/v1/pleroma/chats/1/messages/7":
+			fmt.Fprintln(w, `{"id": "7", "chat_id": "1", "content": "oops"}`)
+		case "POST /api/v1/pleroma/chats/1/read":
+			if r.PostFormValue("last_read_id") == "" {
+				fmt.Fprintln(w, `{"id": "1", "unread": 0}`)
+			} else {
+				fmt.Fprintln(w, `{"id": "1", "unread": 1}`)
+			}
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	ctx := context.Background()
+
+	messages, err := client.PlGetChatMessages(ctx, "1", &Pagination{MaxID: "5"})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("result should be two: %d", len(messages))
+	}
+	if messages[0].Attachment == nil || messages[0].Attachment.ID != "6" || len(messages[0].Emojis) != 1 {
+		t.Fatalf("want message with attachment but %v", messages[0])
+	}
+	if messages[1].Attachment != nil {
+		t.Fatalf("want nil but %v", messages[1].Attachment)
+	}
+
+	message, err := client.PlPostChatMessage(ctx, "1", "oops", "")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if message.Content != "oops" {
+		t.Fatalf("want %q but %q", "oops", message.Content)
+	}
+	message, err = client.PlPostChatMessage(ctx, "1", "", "6")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if message.Attachment.ID != "6" {
+		t.Fatalf("want %q but %q", "6", message.Attachment.ID)
+	}
+
+	message, err = client.PlDeleteChatMessage(ctx, "1", "7")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if message.ID != "7" {
+		t.Fatalf("want %q but %q", "7", message.ID)
+	}
+	_, err = client.PlDeleteChatMessage(ctx, "1", "8")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+
+	chat, err := client.PlMarkChatRead(ctx, "1", "")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if chat.Unread != 0 {
+		t.Fatalf("want %d but %d", 0, chat.Unread)
+	}
+	chat, err = client.PlMarkChatRead(ctx, "1", "4")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if chat.Unread != 1 {
+		t.Fatalf("want %d but %d", 1, chat.Unread)
+	}
+}
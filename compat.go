@@ -29,3 +29,21 @@ func (s *Sbool) UnmarshalJSON(data []byte) error {
 	*s = Sbool(b)
 	return nil
 }
+
+// Sint is an integer that may be encoded as a JSON string.
+type Sint int64
+
+func (s *Sint) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	if string(data) == "null" || len(data) == 0 {
+		return nil
+	}
+	i, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+	*s = Sint(i)
+	return nil
+}
@@ -0,0 +1,189 @@
+package masta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// EmojiPack holds a Pleroma emoji pack. Files maps shortcodes to the file
+// names of the emoji in the pack.
+type EmojiPack struct {
+	Files      map[string]string `json:"files"`
+	FilesCount int64             `json:"files_count"`
+	Pack       EmojiPackMetadata `json:"pack"`
+}
+
+// EmojiPackMetadata holds the metadata of an emoji pack. CanDownload is
+// set by the server.
+type EmojiPackMetadata struct {
+	Description       string `json:"description,omitempty"`
+	Homepage          string `json:"homepage,omitempty"`
+	License           string `json:"license,omitempty"`
+	ShareFiles        bool   `json:"share-files"`
+	CanDownload       bool   `json:"can-download,omitempty"`
+	DownloadSHA256    string `json:"download-sha256,omitempty"`
+	FallbackSrc       string `json:"fallback-src,omitempty"`
+	FallbackSrcSHA256 string `json:"fallback-src-sha256,omitempty"`
+}
+
+// EmojiPacks holds a page of emoji packs by name, and the total number of
+// packs.
+type EmojiPacks struct {
+	Count int64                 `json:"count"`
+	Packs map[string]*EmojiPack `json:"packs"`
+}
+
+// PlGetEmojiPacks returns a page of the emoji packs on the instance. Zero
+// values use the server's defaults.
+func (c *Client) PlGetEmojiPacks(ctx context.Context, page, pageSize int) (*EmojiPacks, error) {
+	var packs EmojiPacks
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/pleroma/emoji/packs", pageParams(page, pageSize), &packs, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &packs, nil
+}
+
+// PlGetEmojiPack returns an emoji pack, with a page of its files.
+func (c *Client) PlGetEmojiPack(ctx context.Context, name string, page, pageSize int) (*EmojiPack, error) {
+	params := pageParams(page, pageSize)
+	params.Set("name", name)
+
+	var pack EmojiPack
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/pleroma/emoji/packs/pack", params, &pack, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pack, nil
+}
+
+// emojiPackURI returns the uri of an emoji pack endpoint, with the pack
+// name in the query string. Pleroma reads it from there for every method.
+func emojiPackURI(endpoint, name string) string {
+	query := url.Values{}
+	query.Set("name", name)
+	return "/api/v1/pleroma/emoji/packs/" + endpoint + "?" + query.Encode()
+}
+
+// PlCreateEmojiPack creates an empty emoji pack.
+func (c *Client) PlCreateEmojiPack(ctx context.Context, name string) error {
+	return c.doAPI(ctx, http.MethodPost, emojiPackURI("pack", name), nil, nil, nil)
+}
+
+// PlUpdateEmojiPack replaces the metadata of an emoji pack.
+func (c *Client) PlUpdateEmojiPack(ctx context.Context, name string, metadata *EmojiPackMetadata) (*EmojiPackMetadata, error) {
+	if metadata == nil {
+		return nil, errors.New("metadata can't be nil")
+	}
+	body, err := json.Marshal(struct {
+		Metadata *EmojiPackMetadata `json:"metadata"`
+	}{metadata})
+	if err != nil {
+		return nil, err
+	}
+
+	var updated EmojiPackMetadata
+	err = c.doAPI(ctx, http.MethodPatch, emojiPackURI("pack", name), json.RawMessage(body), &updated, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// PlDeleteEmojiPack deletes an emoji pack and its files.
+func (c *Client) PlDeleteEmojiPack(ctx context.Context, name string) error {
+	return c.doAPI(ctx, http.MethodDelete, emojiPackURI("pack", name), nil, nil, nil)
+}
+
+// PlAddEmoji uploads file as an emoji to a pack, and returns the files in
+// the pack. If shortcode or filename are empty, they are derived from the
+// uploaded file's name, which is only sent if file is an *os.File.
+func (c *Client) PlAddEmoji(ctx context.Context, pack, shortcode, filename string, file io.Reader) (map[string]string, error) {
+	if file == nil {
+		return nil, errors.New("file can't be nil")
+	}
+	params := url.Values{}
+	stradd := addParamString(&params)
+	stradd("shortcode", shortcode)
+	stradd("filename", filename)
+	form := &multipartForm{values: params, files: []multipartFile{{field: "file", file: file}}}
+
+	var files map[string]string
+	err := c.doAPI(ctx, http.MethodPost, emojiPackURI("files", pack), form, &files, nil)
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// PlUpdateEmoji renames an emoji in a pack, and returns the files in the
+// pack. If force is true, an existing emoji with newShortcode is replaced.
+func (c *Client) PlUpdateEmoji(ctx context.Context, pack, shortcode, newShortcode, newFilename string, force bool) (map[string]string, error) {
+	params := url.Values{}
+	params.Set("shortcode", shortcode)
+	params.Set("new_shortcode", newShortcode)
+	params.Set("new_filename", newFilename)
+	if force {
+		params.Set("force", "true")
+	}
+
+	var files map[string]string
+	err := c.doAPI(ctx, http.MethodPatch, emojiPackURI("files", pack), params, &files, nil)
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// PlRemoveEmoji removes an emoji from a pack, and returns the files left in
+// the pack.
+func (c *Client) PlRemoveEmoji(ctx context.Context, pack, shortcode string) (map[string]string, error) {
+	uri := emojiPackURI("files", pack) + "&shortcode=" + url.QueryEscape(shortcode)
+
+	var files map[string]string
+	err := c.doAPI(ctx, http.MethodDelete, uri, nil, &files, nil)
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// PlImportEmojiPacks imports the emoji packs found in the instance's emoji
+// directory, and returns their names.
+func (c *Client) PlImportEmojiPacks(ctx context.Context) ([]string, error) {
+	var names []string
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/pleroma/emoji/packs/import", nil, &names, nil)
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// PlGetRemoteEmojiPacks returns a page of the emoji packs on the instance at
+// rawurl.
+func (c *Client) PlGetRemoteEmojiPacks(ctx context.Context, rawurl string, page, pageSize int) (*EmojiPacks, error) {
+	params := pageParams(page, pageSize)
+	params.Set("url", rawurl)
+
+	var packs EmojiPacks
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/pleroma/emoji/packs/remote", params, &packs, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &packs, nil
+}
+
+// PlDownloadEmojiPack downloads the emoji pack called name from the
+// instance at rawurl. If as is non-empty, the pack is saved under that name.
+func (c *Client) PlDownloadEmojiPack(ctx context.Context, rawurl, name, as string) error {
+	params := url.Values{}
+	params.Set("url", rawurl)
+	params.Set("name", name)
+	stradd := addParamString(&params)
+	stradd("as", as)
+	return c.doAPI(ctx, http.MethodPost, "/api/v1/pleroma/emoji/packs/download", params, nil, nil)
+}
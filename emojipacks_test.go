@@ -0,0 +1,192 @@
+package masta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPlEmojiPacks(t *testing.T) {
+	// Pleroma reads the pack name from the query string for every method,
+	// and the other parameters from the body.
+	var query, body url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		body = nil
+		if r.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+			b, _ := ioutil.ReadAll(r.Body)
+			body, _ = url.ParseQuery(string(b))
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/pleroma/emoji/packs", "GET /api/v1/pleroma/emoji/packs/remote":
+			fmt.Fprintln(w, `{"count": 1, "packs": {"blobs": {"files": {"blobfox": "blobfox.png"}, "files_count": 1, "pack": {"license": "Apache 2.0", "share-files": true, "can-download": true}}}}`)
+		case "GET /api/v1/pleroma/emoji/packs/pack":
+			fmt.Fprintln(w, `{"files": {"blobfox": "blobfox.png", "blobcat": "cat/blobcat.png"}, "files_count": 2, "pack": {"description": "Blobs", "share-files": false}}`)
+		case "POST /api/v1/pleroma/emoji/packs/pack", "DELETE /api/v1/pleroma/emoji/packs/pack", "POST /api/v1/pleroma/emoji/packs/download":
+			fmt.Fprintln(w, `"ok"`)
+		case "PATCH /api/v1/pleroma/emoji/packs/pack":
+			var update struct {
+				Metadata EmojiPackMetadata `json:"metadata"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil || query.Get("name") != "blobs" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(update.Metadata)
+		case "POST /api/v1/pleroma/emoji/packs/files":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			body = r.MultipartForm.Value
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if b, _ := ioutil.ReadAll(file); string(b) != "png" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `{"blobfox": "blobfox.png"}`)
+		case "PATCH /api/v1/pleroma/emoji/packs/files":
+			fmt.Fprintln(w, `{"fox": "fox.png"}`)
+		case "DELETE /api/v1/pleroma/emoji/packs/files":
+			fmt.Fprintln(w, `{}`)
+		case "GET /api/v1/pleroma/emoji/packs/import":
+			fmt.Fprintln(w, `["blobs", "cats"]`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	ctx := context.Background()
+
+	packs, err := client.PlGetEmojiPacks(ctx, 2, 10)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if query.Get("page") != "2" || query.Get("page_size") != "10" {
+		t.Fatalf("want page 2 of 10 but %v", query)
+	}
+	if packs.Count != 1 || packs.Packs["blobs"] == nil {
+		t.Fatalf("want blobs but %#v", packs)
+	}
+	if pack := packs.Packs["blobs"]; pack.Files["blobfox"] != "blobfox.png" || !pack.Pack.ShareFiles || !pack.Pack.CanDownload || pack.Pack.License != "Apache 2.0" {
+		t.Fatalf("want blobfox but %#v", pack)
+	}
+
+	pack, err := client.PlGetEmojiPack(ctx, "blobs", 0, 0)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if query.Get("name") != "blobs" || query.Get("page") != "" {
+		t.Fatalf("want name blobs but %v", query)
+	}
+	if pack.FilesCount != 2 || pack.Files["blobcat"] != "cat/blobcat.png" || pack.Pack.Description != "Blobs" {
+		t.Fatalf("want 2 files but %#v", pack)
+	}
+
+	if err := client.PlCreateEmojiPack(ctx, "blobs"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if query.Get("name") != "blobs" {
+		t.Fatalf("want name blobs in the query but %v", query)
+	}
+
+	metadata, err := client.PlUpdateEmojiPack(ctx, "blobs", &EmojiPackMetadata{Description: "Foxes", ShareFiles: true})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if metadata.Description != "Foxes" || !metadata.ShareFiles {
+		t.Fatalf("want Foxes but %#v", metadata)
+	}
+	if _, err := client.PlUpdateEmojiPack(ctx, "blobs", nil); err == nil {
+		t.Fatalf("should be fail")
+	}
+
+	if err := client.PlDeleteEmojiPack(ctx, "blobs"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if query.Get("name") != "blobs" {
+		t.Fatalf("want name blobs in the query but %v", query)
+	}
+
+	files, err := client.PlAddEmoji(ctx, "blobs", "blobfox", "", strings.NewReader("png"))
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if query.Get("name") != "blobs" || body.Get("shortcode") != "blobfox" {
+		t.Fatalf("want blobfox but %v %v", query, body)
+	}
+	if _, ok := body["filename"]; ok {
+		t.Fatalf("filename should not be sent: %v", body)
+	}
+	if files["blobfox"] != "blobfox.png" {
+		t.Fatalf("want blobfox but %v", files)
+	}
+	if _, err := client.PlAddEmoji(ctx, "blobs", "blobfox", "", nil); err == nil {
+		t.Fatalf("should be fail")
+	}
+
+	files, err = client.PlUpdateEmoji(ctx, "blobs", "blobfox", "fox", "fox.png", true)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if query.Get("name") != "blobs" {
+		t.Fatalf("want name blobs in the query but %v", query)
+	}
+	if body.Get("shortcode") != "blobfox" || body.Get("new_shortcode") != "fox" || body.Get("new_filename") != "fox.png" || body.Get("force") != "true" {
+		t.Fatalf("want rename to fox but %v", body)
+	}
+	if files["fox"] != "fox.png" {
+		t.Fatalf("want fox but %v", files)
+	}
+
+	files, err = client.PlRemoveEmoji(ctx, "blobs", "fox")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if query.Get("name") != "blobs" || query.Get("shortcode") != "fox" {
+		t.Fatalf("want fox in the query but %v", query)
+	}
+	if len(files) != 0 {
+		t.Fatalf("want no files but %v", files)
+	}
+
+	names, err := client.PlImportEmojiPacks(ctx)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(names) != 2 || names[0] != "blobs" || names[1] != "cats" {
+		t.Fatalf("want blobs and cats but %v", names)
+	}
+
+	packs, err = client.PlGetRemoteEmojiPacks(ctx, "https://example.com", 0, 0)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if query.Get("url") != "https://example.com" || packs.Count != 1 {
+		t.Fatalf("want remote packs but %v %#v", query, packs)
+	}
+
+	if err := client.PlDownloadEmojiPack(ctx, "https://example.com", "blobs", "remote_blobs"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if body.Get("url") != "https://example.com" || body.Get("name") != "blobs" || body.Get("as") != "remote_blobs" {
+		t.Fatalf("want download as remote_blobs but %v", body)
+	}
+}
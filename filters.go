@@ -122,3 +122,240 @@ func (c *Client) UpdateFilter(ctx context.Context, id ID, filter *Filter) (*Filt
 func (c *Client) DeleteFilter(ctx context.Context, id ID) error {
 	return c.doAPI(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/filters/%s", url.PathEscape(string(id))), nil, nil, nil)
 }
+
+// Convenience constants for Filter.Context and FilterV2.Context
+const (
+	FilterContextHome          = "home"
+	FilterContextNotifications = "notifications"
+	FilterContextPublic        = "public"
+	FilterContextThread        = "thread"
+	FilterContextAccount       = "account"
+)
+
+// Convenience constants for FilterV2.FilterAction
+const (
+	FilterActionWarn = "warn"
+	FilterActionHide = "hide"
+)
+
+// FilterV2 is a filter of statuses, matched by keywords or individual statuses.
+type FilterV2 struct {
+	ID           ID              `json:"id"`
+	Title        string          `json:"title"`
+	Context      []string        `json:"context"`
+	ExpiresAt    time.Time       `json:"expires_at"`
+	FilterAction string          `json:"filter_action"`
+	Keywords     []FilterKeyword `json:"keywords"`
+	Statuses     []FilterStatus  `json:"statuses"`
+}
+
+// FilterKeyword is a keyword that is filtered by a FilterV2.
+type FilterKeyword struct {
+	ID        ID     `json:"id"`
+	Keyword   string `json:"keyword"`
+	WholeWord bool   `json:"whole_word"`
+}
+
+// FilterStatus is a status that is filtered by a FilterV2.
+type FilterStatus struct {
+	ID       ID `json:"id"`
+	StatusID ID `json:"status_id"`
+}
+
+// FilterResult holds a filter that matched a status, and what matched.
+type FilterResult struct {
+	Filter         FilterV2 `json:"filter"`
+	KeywordMatches []string `json:"keyword_matches"`
+	StatusMatches  []ID     `json:"status_matches"`
+}
+
+func (f *FilterV2) params(update bool) (url.Values, error) {
+	if f.Title == "" {
+		return nil, errors.New("title can't be empty")
+	}
+	if len(f.Context) == 0 {
+		return nil, errors.New("context can't be empty")
+	}
+
+	params := url.Values{}
+	params.Set("title", f.Title)
+	for _, c := range f.Context {
+		params.Add("context[]", c)
+	}
+	if f.FilterAction != "" {
+		params.Set("filter_action", f.FilterAction)
+	}
+	if !f.ExpiresAt.IsZero() {
+		diff := time.Until(f.ExpiresAt)
+		params.Set("expires_in", fmt.Sprintf("%.0f", diff.Seconds()))
+	} else if update {
+		params.Set("expires_in", "")
+	}
+	for i, kw := range f.Keywords {
+		if kw.ID != "" {
+			params.Set(fmt.Sprintf("keywords_attributes[%d][id]", i), kw.ID)
+		}
+		params.Set(fmt.Sprintf("keywords_attributes[%d][keyword]", i), kw.Keyword)
+		params.Set(fmt.Sprintf("keywords_attributes[%d][whole_word]", i), fmt.Sprint(kw.WholeWord))
+	}
+
+	return params, nil
+}
+
+// GetFiltersV2 returns all the v2 filters on the current account.
+func (c *Client) GetFiltersV2(ctx context.Context) ([]*FilterV2, error) {
+	var filters []*FilterV2
+	err := c.doAPI(ctx, http.MethodGet, "/api/v2/filters", nil, &filters, nil)
+	if err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+// GetFilterV2 retrieves a v2 filter by ID.
+func (c *Client) GetFilterV2(ctx context.Context, id ID) (*FilterV2, error) {
+	var filter FilterV2
+	err := c.doAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v2/filters/%s", url.PathEscape(string(id))), nil, &filter, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &filter, nil
+}
+
+// CreateFilterV2 creates a new v2 filter, along with its keywords.
+// FilterV2.Statuses is ignored, use AddFilterStatus instead.
+func (c *Client) CreateFilterV2(ctx context.Context, filter *FilterV2) (*FilterV2, error) {
+	if filter == nil {
+		return nil, errors.New("filter can't be nil")
+	}
+	params, err := filter.params(false)
+	if err != nil {
+		return nil, err
+	}
+
+	var f FilterV2
+	err = c.doAPI(ctx, http.MethodPost, "/api/v2/filters", params, &f, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// UpdateFilterV2 updates a v2 filter. Keywords with an ID are updated and
+// ones without are added, but keywords that are left out aren't removed;
+// use DeleteFilterKeyword for that.
+func (c *Client) UpdateFilterV2(ctx context.Context, id ID, filter *FilterV2) (*FilterV2, error) {
+	if filter == nil {
+		return nil, errors.New("filter can't be nil")
+	}
+	if id == ID("") {
+		return nil, errors.New("ID can't be empty")
+	}
+	params, err := filter.params(true)
+	if err != nil {
+		return nil, err
+	}
+
+	var f FilterV2
+	err = c.doAPI(ctx, http.MethodPut, fmt.Sprintf("/api/v2/filters/%s", url.PathEscape(string(id))), params, &f, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// DeleteFilterV2 removes a v2 filter.
+func (c *Client) DeleteFilterV2(ctx context.Context, id ID) error {
+	return c.doAPI(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/filters/%s", url.PathEscape(string(id))), nil, nil, nil)
+}
+
+// GetFilterKeywords returns the keywords of a v2 filter.
+func (c *Client) GetFilterKeywords(ctx context.Context, filterID ID) ([]*FilterKeyword, error) {
+	var keywords []*FilterKeyword
+	err := c.doAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v2/filters/%s/keywords", url.PathEscape(string(filterID))), nil, &keywords, nil)
+	if err != nil {
+		return nil, err
+	}
+	return keywords, nil
+}
+
+// GetFilterKeyword retrieves a filter keyword by ID.
+func (c *Client) GetFilterKeyword(ctx context.Context, id ID) (*FilterKeyword, error) {
+	var keyword FilterKeyword
+	err := c.doAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v2/filters/keywords/%s", url.PathEscape(string(id))), nil, &keyword, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &keyword, nil
+}
+
+// AddFilterKeyword adds a keyword to a v2 filter.
+func (c *Client) AddFilterKeyword(ctx context.Context, filterID ID, keyword string, wholeWord bool) (*FilterKeyword, error) {
+	params := url.Values{}
+	params.Set("keyword", keyword)
+	params.Set("whole_word", fmt.Sprint(wholeWord))
+
+	var kw FilterKeyword
+	err := c.doAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v2/filters/%s/keywords", url.PathEscape(string(filterID))), params, &kw, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &kw, nil
+}
+
+// UpdateFilterKeyword updates a filter keyword.
+func (c *Client) UpdateFilterKeyword(ctx context.Context, id ID, keyword string, wholeWord bool) (*FilterKeyword, error) {
+	params := url.Values{}
+	params.Set("keyword", keyword)
+	params.Set("whole_word", fmt.Sprint(wholeWord))
+
+	var kw FilterKeyword
+	err := c.doAPI(ctx, http.MethodPut, fmt.Sprintf("/api/v2/filters/keywords/%s", url.PathEscape(string(id))), params, &kw, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &kw, nil
+}
+
+// DeleteFilterKeyword removes a keyword from its filter.
+func (c *Client) DeleteFilterKeyword(ctx context.Context, id ID) error {
+	return c.doAPI(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/filters/keywords/%s", url.PathEscape(string(id))), nil, nil, nil)
+}
+
+// GetFilterStatuses returns the statuses filtered by a v2 filter.
+func (c *Client) GetFilterStatuses(ctx context.Context, filterID ID) ([]*FilterStatus, error) {
+	var statuses []*FilterStatus
+	err := c.doAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v2/filters/%s/statuses", url.PathEscape(string(filterID))), nil, &statuses, nil)
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// GetFilterStatus retrieves a filtered status by the ID of the FilterStatus.
+func (c *Client) GetFilterStatus(ctx context.Context, id ID) (*FilterStatus, error) {
+	var status FilterStatus
+	err := c.doAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v2/filters/statuses/%s", url.PathEscape(string(id))), nil, &status, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// AddFilterStatus adds a status to a v2 filter.
+func (c *Client) AddFilterStatus(ctx context.Context, filterID ID, statusID ID) (*FilterStatus, error) {
+	params := url.Values{}
+	params.Set("status_id", string(statusID))
+
+	var status FilterStatus
+	err := c.doAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v2/filters/%s/statuses", url.PathEscape(string(filterID))), params, &status, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// DeleteFilterStatus removes a status from its filter, id is FilterStatus.ID.
+func (c *Client) DeleteFilterStatus(ctx context.Context, id ID) error {
+	return c.doAPI(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/filters/statuses/%s", url.PathEscape(string(id))), nil, nil, nil)
+}
@@ -2,6 +2,7 @@ package masta
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -340,3 +341,264 @@ func TestDeleteFilter(t *testing.T) {
 		t.Fatalf("should not be fail: %v", err)
 	}
 }
+
+func TestGetFiltersV2(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/filters":
+			fmt.Fprintln(w, `[{"id": "19972", "title": "Test filter", "context": ["home"], "expires_at": "2022-09-20T17:27:39.296Z", "filter_action": "warn", "keywords": [{"id": "1197", "keyword": "bad word", "whole_word": false}], "statuses": [{"id": "1", "status_id": "109031743575371913"}]}]`)
+		case "/api/v2/filters/19972":
+			fmt.Fprintln(w, `{"id": "19972", "title": "Test filter", "context": ["home", "public"], "expires_at": null, "filter_action": "hide", "keywords": [], "statuses": []}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	filters, err := client.GetFiltersV2(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(filters) != 1 {
+		t.Fatalf("result should be one: %d", len(filters))
+	}
+	f := filters[0]
+	if f.Title != "Test filter" {
+		t.Fatalf("want %q but %q", "Test filter", f.Title)
+	}
+	if f.FilterAction != FilterActionWarn {
+		t.Fatalf("want %q but %q", FilterActionWarn, f.FilterAction)
+	}
+	if f.ExpiresAt.IsZero() {
+		t.Fatal("expires_at should not be zero")
+	}
+	if len(f.Keywords) != 1 || f.Keywords[0].Keyword != "bad word" {
+		t.Fatalf("want one keyword but %v", f.Keywords)
+	}
+	if len(f.Statuses) != 1 || f.Statuses[0].StatusID != "109031743575371913" {
+		t.Fatalf("want one status but %v", f.Statuses)
+	}
+
+	_, err = client.GetFilterV2(context.Background(), "1")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	f, err = client.GetFilterV2(context.Background(), "19972")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if f.FilterAction != FilterActionHide {
+		t.Fatalf("want %q but %q", FilterActionHide, f.FilterAction)
+	}
+	if !f.ExpiresAt.IsZero() {
+		t.Fatalf("want zero expiry but %v", f.ExpiresAt)
+	}
+}
+
+func TestCreateAndUpdateFilterV2(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/filters":
+			if _, ok := r.PostForm["expires_in"]; ok {
+				http.Error(w, "expires_in should not be set", http.StatusBadRequest)
+				return
+			}
+			if r.PostFormValue("keywords_attributes[0][keyword]") != "rust" || r.PostFormValue("keywords_attributes[0][whole_word]") != "true" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintf(w, `{"id": "1", "title": %q, "context": ["home"], "filter_action": %q, "keywords": [{"id": "2", "keyword": "rust", "whole_word": true}]}`, r.PostFormValue("title"), r.PostFormValue("filter_action"))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v2/filters/1":
+			if r.PostFormValue("keywords_attributes[0][id]") != "2" || r.PostFormValue("expires_in") == "" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			ctx, _ := json.Marshal(r.PostForm["context[]"])
+			fmt.Fprintf(w, `{"id": "1", "title": %q, "context": %s}`, r.PostFormValue("title"), ctx)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	_, err := client.CreateFilterV2(context.Background(), nil)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	_, err = client.CreateFilterV2(context.Background(), &FilterV2{Context: []string{FilterContextHome}})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	_, err = client.CreateFilterV2(context.Background(), &FilterV2{Title: "crabs"})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	f, err := client.CreateFilterV2(context.Background(), &FilterV2{
+		Title:        "crabs",
+		Context:      []string{FilterContextHome},
+		FilterAction: FilterActionHide,
+		Keywords:     []FilterKeyword{{Keyword: "rust", WholeWord: true}},
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if f.Title != "crabs" || f.FilterAction != FilterActionHide {
+		t.Fatalf("want %q, %q but %q, %q", "crabs", FilterActionHide, f.Title, f.FilterAction)
+	}
+
+	_, err = client.UpdateFilterV2(context.Background(), "", f)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	f.Title = "not crabs"
+	f.Context = []string{FilterContextHome, FilterContextPublic}
+	f.ExpiresAt = time.Now().Add(time.Hour)
+	f, err = client.UpdateFilterV2(context.Background(), f.ID, f)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if f.Title != "not crabs" {
+		t.Fatalf("want %q but %q", "not crabs", f.Title)
+	}
+	if len(f.Context) != 2 {
+		t.Fatalf("result should be two: %d", len(f.Context))
+	}
+}
+
+func TestFilterV2KeywordsAndStatuses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v2/filters/1/keywords":
+			fmt.Fprintln(w, `[{"id": "2", "keyword": "rust", "whole_word": true}]`)
+		case "GET /api/v2/filters/keywords/2":
+			fmt.Fprintln(w, `{"id": "2", "keyword": "rust", "whole_word": true}`)
+		case "POST /api/v2/filters/1/keywords", "PUT /api/v2/filters/keywords/2":
+			fmt.Fprintf(w, `{"id": "3", "keyword": %q, "whole_word": %s}`, r.PostFormValue("keyword"), r.PostFormValue("whole_word"))
+		case "GET /api/v2/filters/1/statuses":
+			fmt.Fprintln(w, `[{"id": "4", "status_id": "1234"}]`)
+		case "GET /api/v2/filters/statuses/4":
+			fmt.Fprintln(w, `{"id": "4", "status_id": "1234"}`)
+		case "POST /api/v2/filters/1/statuses":
+			fmt.Fprintf(w, `{"id": "5", "status_id": %q}`, r.PostFormValue("status_id"))
+		case "DELETE /api/v2/filters/1", "DELETE /api/v2/filters/keywords/2", "DELETE /api/v2/filters/statuses/4":
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	ctx := context.Background()
+
+	keywords, err := client.GetFilterKeywords(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(keywords) != 1 || !keywords[0].WholeWord {
+		t.Fatalf("want one whole word keyword but %v", keywords)
+	}
+	kw, err := client.GetFilterKeyword(ctx, "2")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if kw.Keyword != "rust" {
+		t.Fatalf("want %q but %q", "rust", kw.Keyword)
+	}
+	kw, err = client.AddFilterKeyword(ctx, "1", "crab", false)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if kw.Keyword != "crab" || kw.WholeWord {
+		t.Fatalf("want %q but %q", "crab", kw.Keyword)
+	}
+	kw, err = client.UpdateFilterKeyword(ctx, "2", "ferris", true)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if kw.Keyword != "ferris" || !kw.WholeWord {
+		t.Fatalf("want %q but %q", "ferris", kw.Keyword)
+	}
+
+	statuses, err := client.GetFilterStatuses(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].StatusID != "1234" {
+		t.Fatalf("want one status but %v", statuses)
+	}
+	fs, err := client.GetFilterStatus(ctx, "4")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if fs.StatusID != "1234" {
+		t.Fatalf("want %q but %q", "1234", fs.StatusID)
+	}
+	fs, err = client.AddFilterStatus(ctx, "1", "5678")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if fs.StatusID != "5678" {
+		t.Fatalf("want %q but %q", "5678", fs.StatusID)
+	}
+
+	for _, err := range []error{
+		client.DeleteFilterKeyword(ctx, "2"),
+		client.DeleteFilterStatus(ctx, "4"),
+		client.DeleteFilterV2(ctx, "1"),
+	} {
+		if err != nil {
+			t.Fatalf("should not be fail: %v", err)
+		}
+	}
+	if err := client.DeleteFilterV2(ctx, "2"); err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+}
+
+func TestStatusFiltered(t *testing.T) {
+	var s Status
+	err := json.Unmarshal([]byte(`{"id": "1", "filtered": [{"filter": {"id": "1", "title": "crabs", "filter_action": "warn"}, "keyword_matches": ["rust"], "status_matches": null}]}`), &s)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(s.Filtered) != 1 {
+		t.Fatalf("result should be one: %d", len(s.Filtered))
+	}
+	if s.Filtered[0].Filter.Title != "crabs" {
+		t.Fatalf("want %q but %q", "crabs", s.Filtered[0].Filter.Title)
+	}
+	if s.Filtered[0].KeywordMatches[0] != "rust" {
+		t.Fatalf("want %q but %q", "rust", s.Filtered[0].KeywordMatches[0])
+	}
+	if s.FilterAction() != FilterActionWarn {
+		t.Fatalf("want %q but %q", FilterActionWarn, s.FilterAction())
+	}
+
+	s.Filtered = append(s.Filtered, FilterResult{Filter: FilterV2{FilterAction: FilterActionHide}})
+	if s.FilterAction() != FilterActionHide {
+		t.Fatalf("want %q but %q", FilterActionHide, s.FilterAction())
+	}
+
+	s.Filtered = nil
+	if s.FilterAction() != "" {
+		t.Fatalf("want %q but %q", "", s.FilterAction())
+	}
+}
@@ -0,0 +1,258 @@
+package masta
+
+import (
+	"context"
+	"errors"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FilterSet evaluates filters against statuses on the client. This is
+// needed for v1 filters, which clients are expected to apply themselves,
+// and for streaming, where statuses arrive without Status.Filtered set.
+type FilterSet struct {
+	filters []compiledFilter
+	now     func() time.Time
+}
+
+type compiledFilter struct {
+	filter   FilterV2
+	keywords []compiledKeyword
+	statuses map[ID]bool
+}
+
+type compiledKeyword struct {
+	keyword string
+	re      *regexp.Regexp
+}
+
+// NewFilterSet builds a FilterSet from v1 and/or v2 filters. Irreversible v1
+// filters hide statuses, the others warn about them, as Mastodon does when
+// migrating them to v2.
+func NewFilterSet(v1 []*Filter, v2 []*FilterV2) *FilterSet {
+	fs := &FilterSet{now: time.Now}
+	for _, f := range v1 {
+		action := FilterActionWarn
+		if f.Irreversible {
+			action = FilterActionHide
+		}
+		fs.add(FilterV2{
+			ID:           f.ID,
+			Title:        f.Phrase,
+			Context:      f.Context,
+			ExpiresAt:    f.ExpiresAt,
+			FilterAction: action,
+			Keywords:     []FilterKeyword{{Keyword: f.Phrase, WholeWord: f.WholeWord}},
+		})
+	}
+	for _, f := range v2 {
+		fs.add(*f)
+	}
+	return fs
+}
+
+// GetFilterSet fetches the filters on the current account and builds a
+// FilterSet from them. v1 filters are used if the server doesn't support v2.
+func (c *Client) GetFilterSet(ctx context.Context) (*FilterSet, error) {
+	v2, err := c.GetFiltersV2(ctx)
+	if err == nil {
+		return NewFilterSet(nil, v2), nil
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+		return nil, err
+	}
+
+	v1, err := c.GetFilters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewFilterSet(v1, nil), nil
+}
+
+func (fs *FilterSet) add(f FilterV2) {
+	cf := compiledFilter{filter: f, statuses: map[ID]bool{}}
+	for _, kw := range f.Keywords {
+		if kw.Keyword == "" {
+			continue
+		}
+		cf.keywords = append(cf.keywords, compiledKeyword{
+			keyword: kw.Keyword,
+			re:      keywordRegexp(kw.Keyword, kw.WholeWord),
+		})
+	}
+	for _, st := range f.Statuses {
+		cf.statuses[st.StatusID] = true
+	}
+	fs.filters = append(fs.filters, cf)
+}
+
+var (
+	wordStartRe = regexp.MustCompile(`^[\pL\pN_]`)
+	wordEndRe   = regexp.MustCompile(`[\pL\pN_]$`)
+)
+
+// keywordRegexp matches keyword case-insensitively. Whole word matching
+// only adds boundaries on the sides of the keyword that are word
+// characters, so that i.e. "#tag" or "c++" still match.
+func keywordRegexp(keyword string, wholeWord bool) *regexp.Regexp {
+	expr := regexp.QuoteMeta(keyword)
+	if wholeWord {
+		if wordStartRe.MatchString(keyword) {
+			expr = `(?:^|[^\pL\pN_])` + expr
+		}
+		if wordEndRe.MatchString(keyword) {
+			expr += `(?:[^\pL\pN_]|$)`
+		}
+	}
+	return regexp.MustCompile("(?i)" + expr)
+}
+
+var (
+	breakRe = regexp.MustCompile(`(?i)<br\s*/?>|</p>`)
+	tagRe   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// filterText returns the text of a status that keywords are matched
+// against: its content warning, content, poll options and media
+// descriptions, each on its own line.
+func filterText(s *Status) string {
+	content := tagRe.ReplaceAllString(breakRe.ReplaceAllString(s.Content, "\n"), "")
+	texts := []string{s.SpoilerText, html.UnescapeString(content)}
+	if s.Poll != nil {
+		for _, option := range s.Poll.Options {
+			texts = append(texts, option.Title)
+		}
+	}
+	for _, media := range s.MediaAttachments {
+		texts = append(texts, media.Description)
+	}
+	return strings.Join(texts, "\n")
+}
+
+// Match returns the filters that apply to a status in the given context,
+// which is one of the FilterContext constants. Expired filters are skipped.
+// If the status is a reblog, the reblogged status is evaluated.
+func (fs *FilterSet) Match(s *Status, filterContext string) []FilterResult {
+	if s == nil {
+		return nil
+	}
+	if s.Reblog != nil {
+		s = s.Reblog
+	}
+
+	var (
+		results []FilterResult
+		text    string
+		now     = fs.now()
+	)
+	for _, cf := range fs.filters {
+		if !cf.filter.ExpiresAt.IsZero() && !cf.filter.ExpiresAt.After(now) {
+			continue
+		}
+		if !containsString(cf.filter.Context, filterContext) {
+			continue
+		}
+
+		result := FilterResult{Filter: cf.filter}
+		if cf.statuses[s.ID] {
+			result.StatusMatches = append(result.StatusMatches, s.ID)
+		}
+		if len(cf.keywords) > 0 && text == "" {
+			text = filterText(s)
+		}
+		for _, kw := range cf.keywords {
+			if kw.re.MatchString(text) {
+				result.KeywordMatches = append(result.KeywordMatches, kw.keyword)
+			}
+		}
+		if len(result.KeywordMatches) > 0 || len(result.StatusMatches) > 0 {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Apply adds the filters that match a status in the given context to
+// Status.Filtered, skipping the ones the server already reported, and
+// returns the resulting Status.FilterAction.
+func (fs *FilterSet) Apply(s *Status, filterContext string) string {
+	if s == nil {
+		return ""
+	}
+	for _, result := range fs.Match(s, filterContext) {
+		seen := false
+		for _, existing := range s.Filtered {
+			if existing.Filter.ID == result.Filter.ID {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			s.Filtered = append(s.Filtered, result)
+		}
+	}
+	return s.FilterAction()
+}
+
+// Stream applies the filters to the statuses of update, status.update and
+// notification events read from q. Updates are filtered in the given
+// context, and notifications in FilterContextNotifications, so a user
+// stream carrying both can be filtered at once. Events with statuses that
+// are hidden are dropped, the others are passed through with
+// Status.Filtered set. The returned channel is closed when q is closed or
+// ctx is done.
+func (fs *FilterSet) Stream(ctx context.Context, q chan Event, filterContext string) chan Event {
+	out := make(chan Event)
+	go func() {
+		defer close(out)
+		for {
+			var e Event
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-q:
+				if !ok {
+					return
+				}
+				e = ev
+			}
+
+			var s *Status
+			sContext := filterContext
+			switch t := e.(type) {
+			case *UpdateEvent:
+				s = t.Status
+			case *UpdateEditEvent:
+				s = t.Status
+			case *NotificationEvent:
+				if t.Notification != nil {
+					s = t.Notification.Status
+				}
+				sContext = FilterContextNotifications
+			}
+			if fs.Apply(s, sContext) == FilterActionHide {
+				continue
+			}
+
+			select {
+			case out <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,238 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFilterSetMatch(t *testing.T) {
+	now := time.Date(2022, 9, 20, 0, 0, 0, 0, time.UTC)
+	fs := NewFilterSet([]*Filter{
+		{ID: "1", Phrase: "cat", Context: []string{FilterContextHome}, WholeWord: true},
+		{ID: "2", Phrase: "dog", Context: []string{FilterContextHome}, Irreversible: true},
+		{ID: "3", Phrase: "bird", Context: []string{FilterContextHome}, ExpiresAt: now.Add(-time.Hour)},
+	}, []*FilterV2{
+		{
+			ID:           "4",
+			Title:        "crabs",
+			Context:      []string{FilterContextPublic, FilterContextThread},
+			FilterAction: FilterActionWarn,
+			Keywords:     []FilterKeyword{{Keyword: "#rust", WholeWord: true}, {Keyword: "ferris"}},
+			Statuses:     []FilterStatus{{StatusID: "99"}},
+		},
+	})
+	fs.now = func() time.Time { return now }
+
+	tests := []struct {
+		name    string
+		status  *Status
+		context string
+		want    []ID
+	}{
+		{"whole word", &Status{Content: "<p>a cat</p>"}, FilterContextHome, []ID{"1"}},
+		{"whole word in word", &Status{Content: "<p>concatenate</p>"}, FilterContextHome, nil},
+		{"case insensitive", &Status{Content: "<p>CAT!</p>"}, FilterContextHome, []ID{"1"}},
+		{"not whole word", &Status{Content: "<p>hotdogs</p>"}, FilterContextHome, []ID{"2"}},
+		{"other context", &Status{Content: "<p>cat</p>"}, FilterContextPublic, nil},
+		{"expired", &Status{Content: "<p>bird</p>"}, FilterContextHome, nil},
+		{"html entities", &Status{Content: "<p>cat&amp;dog</p>"}, FilterContextHome, []ID{"1", "2"}},
+		{"spoiler text", &Status{SpoilerText: "cat"}, FilterContextHome, []ID{"1"}},
+		{"poll", &Status{Poll: &Poll{Options: []PollOption{{Title: "cat"}}}}, FilterContextHome, []ID{"1"}},
+		{"media description", &Status{MediaAttachments: []Attachment{{Description: "a dog"}}}, FilterContextHome, []ID{"2"}},
+		{"hashtag", &Status{Content: `<p><a href="https://example.com/tags/rust">#<span>rust</span></a></p>`}, FilterContextPublic, []ID{"4"}},
+		{"line break", &Status{Content: "<p>a<br>cat</p><p>b</p>"}, FilterContextHome, []ID{"1"}},
+		{"hashtag prefix", &Status{Content: "<p>#rustlang</p>"}, FilterContextPublic, nil},
+		{"status", &Status{ID: "99"}, FilterContextThread, []ID{"4"}},
+		{"reblog", &Status{ID: "100", Reblog: &Status{ID: "99"}}, FilterContextThread, []ID{"4"}},
+		{"nil", nil, FilterContextHome, nil},
+	}
+	for _, test := range tests {
+		results := fs.Match(test.status, test.context)
+		var got []ID
+		for _, result := range results {
+			got = append(got, result.Filter.ID)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(test.want) {
+			t.Errorf("%s: want %v but %v", test.name, test.want, got)
+		}
+	}
+
+	results := fs.Match(&Status{Content: "<p>Ferris</p>"}, FilterContextPublic)
+	if len(results) != 1 || len(results[0].KeywordMatches) != 1 || results[0].KeywordMatches[0] != "ferris" {
+		t.Fatalf("want keyword match %q but %v", "ferris", results)
+	}
+	results = fs.Match(&Status{ID: "99"}, FilterContextPublic)
+	if len(results) != 1 || len(results[0].StatusMatches) != 1 || results[0].StatusMatches[0] != "99" {
+		t.Fatalf("want status match %q but %v", "99", results)
+	}
+}
+
+func TestFilterSetApply(t *testing.T) {
+	fs := NewFilterSet([]*Filter{
+		{ID: "1", Phrase: "cat", Context: []string{FilterContextHome}},
+		{ID: "2", Phrase: "dog", Context: []string{FilterContextHome}, Irreversible: true},
+	}, nil)
+
+	s := &Status{Content: "<p>cat</p>", Filtered: []FilterResult{{Filter: FilterV2{ID: "1", FilterAction: FilterActionWarn}}}}
+	if action := fs.Apply(s, FilterContextHome); action != FilterActionWarn {
+		t.Fatalf("want %q but %q", FilterActionWarn, action)
+	}
+	if len(s.Filtered) != 1 {
+		t.Fatalf("result should be one: %d", len(s.Filtered))
+	}
+
+	s = &Status{Content: "<p>cat and dog</p>"}
+	if action := fs.Apply(s, FilterContextHome); action != FilterActionHide {
+		t.Fatalf("want %q but %q", FilterActionHide, action)
+	}
+	if len(s.Filtered) != 2 {
+		t.Fatalf("result should be two: %d", len(s.Filtered))
+	}
+
+	if action := fs.Apply(&Status{Content: "<p>bird</p>"}, FilterContextHome); action != "" {
+		t.Fatalf("want %q but %q", "", action)
+	}
+	if action := fs.Apply(nil, FilterContextHome); action != "" {
+		t.Fatalf("want %q but %q", "", action)
+	}
+}
+
+func TestFilterSetStream(t *testing.T) {
+	fs := NewFilterSet([]*Filter{
+		{ID: "1", Phrase: "cat", Context: []string{FilterContextHome}},
+		{ID: "2", Phrase: "dog", Context: []string{FilterContextHome, FilterContextNotifications}, Irreversible: true},
+	}, nil)
+
+	q := make(chan Event)
+	go func() {
+		defer close(q)
+		q <- &UpdateEvent{&Status{ID: "1", Content: "dog"}}
+		q <- &UpdateEvent{&Status{ID: "2", Content: "cat"}}
+		q <- &UpdateEditEvent{&Status{ID: "3", Content: "dog"}}
+		q <- &NotificationEvent{&Notification{ID: "4", Status: &Status{Content: "dog"}}}
+		q <- &NotificationEvent{&Notification{ID: "5", Type: "follow"}}
+		q <- &DeleteEvent{ID: "6"}
+	}()
+
+	var events []Event
+	for e := range fs.Stream(context.Background(), q, FilterContextHome) {
+		events = append(events, e)
+	}
+	if len(events) != 3 {
+		t.Fatalf("result should be three: %d", len(events))
+	}
+	update, ok := events[0].(*UpdateEvent)
+	if !ok {
+		t.Fatalf("want *UpdateEvent but %T", events[0])
+	}
+	if update.Status.ID != "2" || update.Status.FilterAction() != FilterActionWarn {
+		t.Fatalf("want status %q to be warned about but %v", "2", update.Status)
+	}
+	if n, ok := events[1].(*NotificationEvent); !ok || n.Notification.ID != "5" {
+		t.Fatalf("want notification %q but %v", "5", events[1])
+	}
+	if d, ok := events[2].(*DeleteEvent); !ok || d.ID != "6" {
+		t.Fatalf("want delete %q but %v", "6", events[2])
+	}
+}
+
+func TestFilterSetStreamMixed(t *testing.T) {
+	fs := NewFilterSet([]*Filter{
+		{ID: "1", Phrase: "cat", Context: []string{FilterContextHome}, Irreversible: true},
+		{ID: "2", Phrase: "dog", Context: []string{FilterContextNotifications}, Irreversible: true},
+	}, nil)
+
+	q := make(chan Event)
+	go func() {
+		defer close(q)
+		q <- &UpdateEvent{&Status{ID: "1", Content: "cat"}}
+		q <- &UpdateEvent{&Status{ID: "2", Content: "dog"}}
+		q <- &NotificationEvent{&Notification{ID: "3", Status: &Status{Content: "cat"}}}
+		q <- &NotificationEvent{&Notification{ID: "4", Status: &Status{Content: "dog"}}}
+	}()
+
+	var events []Event
+	for e := range fs.Stream(context.Background(), q, FilterContextHome) {
+		events = append(events, e)
+	}
+	if len(events) != 2 {
+		t.Fatalf("result should be two: %d", len(events))
+	}
+	if u, ok := events[0].(*UpdateEvent); !ok || u.Status.ID != "2" {
+		t.Fatalf("want update %q but %v", "2", events[0])
+	}
+	if n, ok := events[1].(*NotificationEvent); !ok || n.Notification.ID != "3" {
+		t.Fatalf("want notification %q but %v", "3", events[1])
+	}
+}
+
+func TestFilterSetStreamCancel(t *testing.T) {
+	fs := NewFilterSet(nil, nil)
+	q := make(chan Event, 1)
+	q <- &DeleteEvent{ID: "1"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := fs.Stream(ctx, q, FilterContextHome)
+	cancel()
+
+	// q is never closed, so out is only closed because ctx is done.
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-out:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("stream should be closed when ctx is done")
+		}
+	}
+}
+
+func TestGetFilterSet(t *testing.T) {
+	v2 := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/filters" && v2:
+			fmt.Fprintln(w, `[{"id": "1", "title": "crabs", "context": ["home"], "filter_action": "hide", "keywords": [{"id": "2", "keyword": "rust", "whole_word": true}]}]`)
+		case r.URL.Path == "/api/v1/filters":
+			fmt.Fprintln(w, `[{"id": "3", "phrase": "rust", "context": ["public"], "whole_word": true, "irreversible": false}]`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	s := &Status{Content: "<p>rust</p>"}
+
+	fs, err := client.GetFilterSet(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if action := fs.Apply(s, FilterContextHome); action != FilterActionHide {
+		t.Fatalf("want %q but %q", FilterActionHide, action)
+	}
+
+	v2 = false
+	fs, err = client.GetFilterSet(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(fs.Match(s, FilterContextHome)) != 0 {
+		t.Fatal("v1 filter should not match in home")
+	}
+	results := fs.Match(s, FilterContextPublic)
+	if len(results) != 1 || results[0].Filter.ID != "3" || results[0].Filter.FilterAction != FilterActionWarn {
+		t.Fatalf("want v1 filter %q to warn but %v", "3", results)
+	}
+}
@@ -1,6 +1,7 @@
 package masta
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -8,6 +9,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
+	"strings"
 )
 
 func addParamString(params *url.Values) func(key, value string) {
@@ -30,6 +33,57 @@ func addParamFuncs(params *url.Values) (func(key, value string), func(key string
 	return addParamString(params), addParamBool(params)
 }
 
+// offsetParams returns the parameters for offset based pagination, as used
+// by trends and suggestions. Zero values use the server's defaults.
+func offsetParams(offset, limit int) url.Values {
+	params := url.Values{}
+	if offset > 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	return params
+}
+
+// pageParams returns the parameters for page based pagination, as used by
+// Pleroma. Zero values use the server's defaults.
+func pageParams(page, pageSize int) url.Values {
+	params := url.Values{}
+	if page > 0 {
+		params.Set("page", strconv.Itoa(page))
+	}
+	if pageSize > 0 {
+		params.Set("page_size", strconv.Itoa(pageSize))
+	}
+	return params
+}
+
+// nestParams turns form parameters with bracketed keys, such as
+// "source[privacy]" or "ids[]", into nested objects for a JSON body.
+func nestParams(params url.Values) map[string]interface{} {
+	obj := map[string]interface{}{}
+	for key, values := range params {
+		path := strings.Split(strings.ReplaceAll(key, "]", ""), "[")
+		var value interface{} = values[0]
+		if len(path) > 1 && path[len(path)-1] == "" {
+			path = path[:len(path)-1]
+			value = values
+		}
+		parent := obj
+		for _, k := range path[:len(path)-1] {
+			child, ok := parent[k].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				parent[k] = child
+			}
+			parent = child
+		}
+		parent[path[len(path)-1]] = value
+	}
+	return obj
+}
+
 // Base64EncodeFileName returns the base64 data URI format string of the file with the file name.
 func Base64EncodeFileName(filename string) (string, error) {
 	file, err := os.Open(filename)
@@ -77,3 +131,30 @@ func parseAPIError(prefix string, resp *http.Response) error {
 		errors.New(errMsg),
 	}
 }
+
+// getJSON fetches an absolute URL without authentication and decodes the
+// JSON response into res. It's used for endpoints that live outside of the
+// configured server's API, such as NodeInfo and WebFinger documents.
+func getJSON(ctx context.Context, client *http.Client, userAgent, rawurl string, res interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, rawurl, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return parseAPIError("bad request", resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(res)
+}
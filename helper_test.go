@@ -1,8 +1,10 @@
 package masta
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
@@ -87,3 +89,21 @@ func TestParseAPIError(t *testing.T) {
 		t.Fatalf("want %q but %q", want, err.Error())
 	}
 }
+
+func TestNestParams(t *testing.T) {
+	params := url.Values{}
+	params.Set("display_name", "foo")
+	params.Set("source[privacy]", "public")
+	params.Set("fields_attributes[0][name]", "bar")
+	params.Add("ids[]", "1")
+	params.Add("ids[]", "2")
+
+	got, err := json.Marshal(nestParams(params))
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	want := `{"display_name":"foo","fields_attributes":{"0":{"name":"bar"}},"ids":["1","2"],"source":{"privacy":"public"}}`
+	if string(got) != want {
+		t.Fatalf("want %s but %s", want, got)
+	}
+}
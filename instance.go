@@ -2,7 +2,12 @@ package masta
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 )
 
 // Instance holds information for a mastodon instance.
@@ -18,6 +23,29 @@ type Instance struct {
 	Languages      []string          `json:"languages"`
 	ContactAccount *Account          `json:"contact_account"`
 	Configuration  *InstanceConfig   `json:"configuration"`
+
+	Registrations    bool `json:"registrations"`
+	ApprovalRequired bool `json:"approval_required"`
+
+	// Pleroma-exclusive fields
+
+	MaxTootChars int64 `json:"max_toot_chars,omitempty"`
+	UploadLimit  int64 `json:"upload_limit,omitempty"`
+	PollLimits   *struct {
+		MaxOptions     int64 `json:"max_options"`
+		MaxOptionChars int64 `json:"max_option_chars"`
+		MinExpiration  int64 `json:"min_expiration"`
+		MaxExpiration  int64 `json:"max_expiration"`
+	} `json:"poll_limits,omitempty"`
+
+	Pleroma *struct {
+		Metadata struct {
+			AccountActivationRequired bool     `json:"account_activation_required"`
+			Features                  []string `json:"features"`
+			PostFormats               []string `json:"post_formats"`
+		} `json:"metadata"`
+		VapidPublicKey string `json:"vapid_public_key"`
+	} `json:"pleroma"`
 }
 
 type InstanceConfigMap map[string]int
@@ -52,6 +80,215 @@ func (c *Instance) GetConfig() *InstanceConfig {
 	return c.Configuration
 }
 
+// InstanceV2 holds information for a mastodon instance, as returned by
+// the v2 instance API.
+type InstanceV2 struct {
+	Domain        string                `json:"domain"`
+	Title         string                `json:"title"`
+	Version       string                `json:"version"`
+	SourceURL     string                `json:"source_url"`
+	Description   string                `json:"description"`
+	Usage         InstanceUsage         `json:"usage"`
+	Thumbnail     InstanceThumbnail     `json:"thumbnail"`
+	Languages     []string              `json:"languages"`
+	Configuration InstanceConfigV2      `json:"configuration"`
+	Registrations InstanceRegistrations `json:"registrations"`
+	Contact       InstanceContact       `json:"contact"`
+	Rules         []InstanceRule        `json:"rules"`
+}
+
+// InstanceUsage holds usage statistics for an instance.
+type InstanceUsage struct {
+	Users struct {
+		ActiveMonth int64 `json:"active_month"`
+	} `json:"users"`
+}
+
+// InstanceThumbnail holds the instance's banner image. Versions maps
+// resolutions such as "@1x" and "@2x" to URLs.
+type InstanceThumbnail struct {
+	URL      string            `json:"url"`
+	Blurhash string            `json:"blurhash"`
+	Versions map[string]string `json:"versions"`
+}
+
+// InstanceRegistrations holds information about registering on an instance.
+type InstanceRegistrations struct {
+	Enabled          bool    `json:"enabled"`
+	ApprovalRequired bool    `json:"approval_required"`
+	Message          *string `json:"message"`
+}
+
+// InstanceContact holds the instance's contact information.
+type InstanceContact struct {
+	Email   string   `json:"email"`
+	Account *Account `json:"account"`
+}
+
+// InstanceRule is a rule users of an instance agree to follow.
+type InstanceRule struct {
+	ID   ID     `json:"id"`
+	Text string `json:"text"`
+	Hint string `json:"hint"`
+}
+
+// InstanceConfigV2 holds the limits and settings of an instance.
+type InstanceConfigV2 struct {
+	URLs             InstanceURLsConfig        `json:"urls"`
+	Accounts         InstanceAccountsConfig    `json:"accounts"`
+	Statuses         InstanceStatusesConfig    `json:"statuses"`
+	MediaAttachments InstanceMediaConfig       `json:"media_attachments"`
+	Polls            InstancePollsConfig       `json:"polls"`
+	Translation      InstanceTranslationConfig `json:"translation"`
+}
+
+// InstanceURLsConfig holds URLs of interest for clients.
+type InstanceURLsConfig struct {
+	Streaming string `json:"streaming"`
+	Status    string `json:"status"`
+}
+
+// InstanceAccountsConfig holds limits on accounts.
+type InstanceAccountsConfig struct {
+	MaxFeaturedTags   int64 `json:"max_featured_tags"`
+	MaxPinnedStatuses int64 `json:"max_pinned_statuses"`
+}
+
+// InstanceStatusesConfig holds limits on statuses.
+type InstanceStatusesConfig struct {
+	MaxCharacters            int64 `json:"max_characters"`
+	MaxMediaAttachments      int64 `json:"max_media_attachments"`
+	CharactersReservedPerURL int64 `json:"characters_reserved_per_url"`
+}
+
+// InstanceMediaConfig holds limits on media attachments. Sizes are in
+// bytes and matrix limits are in pixels (width * height).
+type InstanceMediaConfig struct {
+	SupportedMIMETypes  []string `json:"supported_mime_types"`
+	ImageSizeLimit      int64    `json:"image_size_limit"`
+	ImageMatrixLimit    int64    `json:"image_matrix_limit"`
+	VideoSizeLimit      int64    `json:"video_size_limit"`
+	VideoFrameRateLimit int64    `json:"video_frame_rate_limit"`
+	VideoMatrixLimit    int64    `json:"video_matrix_limit"`
+}
+
+// InstancePollsConfig holds limits on polls. Expirations are in seconds.
+type InstancePollsConfig struct {
+	MaxOptions             int64 `json:"max_options"`
+	MaxCharactersPerOption int64 `json:"max_characters_per_option"`
+	MinExpiration          int64 `json:"min_expiration"`
+	MaxExpiration          int64 `json:"max_expiration"`
+}
+
+// InstanceTranslationConfig holds whether statuses can be translated.
+type InstanceTranslationConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+// GetInstanceV2 returns InstanceV2. If the server doesn't implement the
+// v2 API, the v1 instance is fetched and mapped onto InstanceV2 instead,
+// in which case fields without a v1 equivalent are left empty.
+func (c *Client) GetInstanceV2(ctx context.Context) (*InstanceV2, error) {
+	var instance InstanceV2
+	err := c.doAPI(ctx, http.MethodGet, "/api/v2/instance", nil, &instance, nil)
+	if err == nil {
+		return &instance, nil
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+		return nil, err
+	}
+
+	v1, err := c.GetInstance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return v1.toV2(), nil
+}
+
+func (c *Instance) toV2() *InstanceV2 {
+	domain := c.URI
+	if i := strings.Index(domain, "://"); i != -1 {
+		domain = domain[i+3:]
+	}
+
+	v2 := &InstanceV2{
+		Domain:      strings.TrimSuffix(domain, "/"),
+		Title:       c.Title,
+		Version:     c.Version,
+		Description: c.Description,
+		Thumbnail:   InstanceThumbnail{URL: c.Thumbnail},
+		Languages:   c.Languages,
+		Registrations: InstanceRegistrations{
+			Enabled:          c.Registrations,
+			ApprovalRequired: c.ApprovalRequired,
+		},
+		Contact: InstanceContact{
+			Email:   c.EMail,
+			Account: c.ContactAccount,
+		},
+	}
+	v2.Configuration.URLs.Streaming = c.URLs["streaming_api"]
+
+	cfg := &v2.Configuration
+	if c.Configuration != nil {
+		if m := c.Configuration.Accounts; m != nil {
+			cfg.Accounts.MaxFeaturedTags = int64((*m)["max_featured_tags"])
+			cfg.Accounts.MaxPinnedStatuses = int64((*m)["max_pinned_statuses"])
+		}
+		if m := c.Configuration.Statuses; m != nil {
+			cfg.Statuses.MaxCharacters = int64((*m)["max_characters"])
+			cfg.Statuses.MaxMediaAttachments = int64((*m)["max_media_attachments"])
+			cfg.Statuses.CharactersReservedPerURL = int64((*m)["characters_reserved_per_url"])
+		}
+		if m := c.Configuration.Polls; m != nil {
+			cfg.Polls.MaxOptions = int64((*m)["max_options"])
+			cfg.Polls.MaxCharactersPerOption = int64((*m)["max_characters_per_option"])
+			cfg.Polls.MinExpiration = int64((*m)["min_expiration"])
+			cfg.Polls.MaxExpiration = int64((*m)["max_expiration"])
+		}
+		if m := c.Configuration.MediaAttachments; m != nil {
+			media := &cfg.MediaAttachments
+			if types, ok := m["supported_mime_types"].([]interface{}); ok {
+				for _, t := range types {
+					if s, ok := t.(string); ok {
+						media.SupportedMIMETypes = append(media.SupportedMIMETypes, s)
+					}
+				}
+			}
+			for key, dst := range map[string]*int64{
+				"image_size_limit":       &media.ImageSizeLimit,
+				"image_matrix_limit":     &media.ImageMatrixLimit,
+				"video_size_limit":       &media.VideoSizeLimit,
+				"video_frame_rate_limit": &media.VideoFrameRateLimit,
+				"video_matrix_limit":     &media.VideoMatrixLimit,
+			} {
+				if n, ok := m[key].(float64); ok {
+					*dst = int64(n)
+				}
+			}
+		}
+	}
+
+	// Pleroma doesn't send a configuration, but has its own fields.
+	if cfg.Statuses.MaxCharacters == 0 {
+		cfg.Statuses.MaxCharacters = c.MaxTootChars
+	}
+	if cfg.MediaAttachments.ImageSizeLimit == 0 && cfg.MediaAttachments.VideoSizeLimit == 0 {
+		cfg.MediaAttachments.ImageSizeLimit = c.UploadLimit
+		cfg.MediaAttachments.VideoSizeLimit = c.UploadLimit
+	}
+	if c.PollLimits != nil && cfg.Polls.MaxOptions == 0 {
+		cfg.Polls.MaxOptions = c.PollLimits.MaxOptions
+		cfg.Polls.MaxCharactersPerOption = c.PollLimits.MaxOptionChars
+		cfg.Polls.MinExpiration = c.PollLimits.MinExpiration
+		cfg.Polls.MaxExpiration = c.PollLimits.MaxExpiration
+	}
+
+	return v2
+}
+
 // WeeklyActivity holds information for mastodon weekly activity.
 type WeeklyActivity struct {
 	Week          Unixtime `json:"week"`
@@ -89,3 +326,93 @@ func (c *Client) GetInstanceEmojis(ctx context.Context) ([]*Emoji, error) {
 	}
 	return emojis, nil
 }
+
+// GetInstanceRules returns the rules of the instance.
+func (c *Client) GetInstanceRules(ctx context.Context) ([]*InstanceRule, error) {
+	var rules []*InstanceRule
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/instance/rules", nil, &rules, nil)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// InstanceDocument holds an HTML document published by an instance, such
+// as its extended description or privacy policy.
+type InstanceDocument struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	Content   string    `json:"content"`
+}
+
+// GetInstanceExtendedDescription returns the extended description of the instance.
+func (c *Client) GetInstanceExtendedDescription(ctx context.Context) (*InstanceDocument, error) {
+	var doc InstanceDocument
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/instance/extended_description", nil, &doc, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// GetInstancePrivacyPolicy returns the privacy policy of the instance.
+func (c *Client) GetInstancePrivacyPolicy(ctx context.Context) (*InstanceDocument, error) {
+	var doc InstanceDocument
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/instance/privacy_policy", nil, &doc, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// Convenience constants for InstanceDomainBlock.Severity and
+// AdminDomainBlock.Severity
+const (
+	DomainBlockSilence = "silence"
+	DomainBlockSuspend = "suspend"
+	DomainBlockNoop    = "noop"
+)
+
+// InstanceDomainBlock holds information for a domain blocked by an instance.
+// If the instance obfuscates its blocks, some characters of Domain are
+// replaced with asterisks and Digest must be used to identify it.
+type InstanceDomainBlock struct {
+	Domain   string `json:"domain"`
+	Digest   string `json:"digest"`
+	Severity string `json:"severity"`
+	Comment  string `json:"comment"`
+}
+
+// Matches reports whether the block applies to domain, by comparing
+// it against Digest.
+func (b *InstanceDomainBlock) Matches(domain string) bool {
+	sum := sha256.Sum256([]byte(strings.ToLower(domain)))
+	return hex.EncodeToString(sum[:]) == strings.ToLower(b.Digest)
+}
+
+// GetInstanceDomainBlocks returns the domains blocked by the instance.
+// Depending on the instance's settings, this may require authentication
+// or be unavailable entirely.
+func (c *Client) GetInstanceDomainBlocks(ctx context.Context) ([]*InstanceDomainBlock, error) {
+	var blocks []*InstanceDomainBlock
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/instance/domain_blocks", nil, &blocks, nil)
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// InstanceLanguage holds a language supported by the instance.
+type InstanceLanguage struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// GetInstanceLanguages returns the languages supported by the instance.
+func (c *Client) GetInstanceLanguages(ctx context.Context) ([]*InstanceLanguage, error) {
+	var languages []*InstanceLanguage
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/instance/languages", nil, &languages, nil)
+	if err != nil {
+		return nil, err
+	}
+	return languages, nil
+}
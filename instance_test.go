@@ -186,3 +186,235 @@ func TestGetInstancePeers(t *testing.T) {
 		t.Fatalf("want %q but %q", "mstdn.jp", peers[1])
 	}
 }
+
+func TestGetInstanceV2(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/instance" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `{"domain": "mstdn.example.com", "title": "mastodon", "version": "4.1.2", "source_url": "https://github.com/mastodon/mastodon", "usage": {"users": {"active_month": 42}}, "thumbnail": {"url": "https://mstdn.example.com/thumb.png", "versions": {"@1x": "https://mstdn.example.com/thumb1.png", "@2x": "https://mstdn.example.com/thumb2.png"}}, "languages": ["en"], "configuration": {"urls": {"streaming": "wss://mstdn.example.com"}, "statuses": {"max_characters": 500, "max_media_attachments": 4, "characters_reserved_per_url": 23}, "media_attachments": {"supported_mime_types": ["image/png", "video/mp4"], "image_size_limit": 10485760, "image_matrix_limit": 16777216, "video_size_limit": 41943040, "video_frame_rate_limit": 60, "video_matrix_limit": 2304000}, "polls": {"max_options": 4, "max_characters_per_option": 50, "min_expiration": 300, "max_expiration": 2629746}, "translation": {"enabled": true}}, "registrations": {"enabled": true, "approval_required": true, "message": null}, "contact": {"email": "mstdn@mstdn.example.com", "account": {"username": "mattn"}}, "rules": [{"id": "1", "text": "Be nice"}]}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	ins, err := client.GetInstanceV2(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if ins.Domain != "mstdn.example.com" {
+		t.Fatalf("want %q but %q", "mstdn.example.com", ins.Domain)
+	}
+	if ins.SourceURL != "https://github.com/mastodon/mastodon" {
+		t.Fatalf("want %q but %q", "https://github.com/mastodon/mastodon", ins.SourceURL)
+	}
+	if ins.Usage.Users.ActiveMonth != 42 {
+		t.Fatalf("want %v but %v", 42, ins.Usage.Users.ActiveMonth)
+	}
+	if ins.Thumbnail.Versions["@2x"] != "https://mstdn.example.com/thumb2.png" {
+		t.Fatalf("want %q but %q", "https://mstdn.example.com/thumb2.png", ins.Thumbnail.Versions["@2x"])
+	}
+	cfg := ins.Configuration
+	if cfg.URLs.Streaming != "wss://mstdn.example.com" {
+		t.Fatalf("want %q but %q", "wss://mstdn.example.com", cfg.URLs.Streaming)
+	}
+	if cfg.Statuses.MaxCharacters != 500 {
+		t.Fatalf("want %v but %v", 500, cfg.Statuses.MaxCharacters)
+	}
+	if len(cfg.MediaAttachments.SupportedMIMETypes) != 2 {
+		t.Fatalf("result should be two: %d", len(cfg.MediaAttachments.SupportedMIMETypes))
+	}
+	if cfg.MediaAttachments.ImageMatrixLimit != 16777216 {
+		t.Fatalf("want %v but %v", 16777216, cfg.MediaAttachments.ImageMatrixLimit)
+	}
+	if cfg.Polls.MaxExpiration != 2629746 {
+		t.Fatalf("want %v but %v", 2629746, cfg.Polls.MaxExpiration)
+	}
+	if !cfg.Translation.Enabled {
+		t.Fatal("want translation enabled")
+	}
+	if !ins.Registrations.ApprovalRequired {
+		t.Fatal("want approval required")
+	}
+	if ins.Contact.Account.Username != "mattn" {
+		t.Fatalf("want %q but %q", "mattn", ins.Contact.Account.Username)
+	}
+	if len(ins.Rules) != 1 || ins.Rules[0].Text != "Be nice" {
+		t.Fatalf("want one rule but %v", ins.Rules)
+	}
+}
+
+func TestGetInstanceV2Fallback(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/instance" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `{"uri": "https://pl.example.com", "title": "pleroma", "email": "admin@pl.example.com", "version": "2.7.2 (compatible; Pleroma 2.5.2)", "urls": {"streaming_api": "wss://pl.example.com"}, "registrations": true, "max_toot_chars": 5000, "upload_limit": 16000000, "poll_limits": {"max_options": 20, "max_option_chars": 200, "min_expiration": 0, "max_expiration": 31536000}, "configuration": {"media_attachments": {"supported_mime_types": ["image/png"]}}}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	ins, err := client.GetInstanceV2(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if ins.Domain != "pl.example.com" {
+		t.Fatalf("want %q but %q", "pl.example.com", ins.Domain)
+	}
+	if ins.Contact.Email != "admin@pl.example.com" {
+		t.Fatalf("want %q but %q", "admin@pl.example.com", ins.Contact.Email)
+	}
+	if !ins.Registrations.Enabled {
+		t.Fatal("want registrations enabled")
+	}
+	cfg := ins.Configuration
+	if cfg.URLs.Streaming != "wss://pl.example.com" {
+		t.Fatalf("want %q but %q", "wss://pl.example.com", cfg.URLs.Streaming)
+	}
+	if cfg.Statuses.MaxCharacters != 5000 {
+		t.Fatalf("want %v but %v", 5000, cfg.Statuses.MaxCharacters)
+	}
+	if cfg.MediaAttachments.ImageSizeLimit != 16000000 {
+		t.Fatalf("want %v but %v", 16000000, cfg.MediaAttachments.ImageSizeLimit)
+	}
+	if len(cfg.MediaAttachments.SupportedMIMETypes) != 1 {
+		t.Fatalf("result should be one: %d", len(cfg.MediaAttachments.SupportedMIMETypes))
+	}
+	if cfg.Polls.MaxOptions != 20 {
+		t.Fatalf("want %v but %v", 20, cfg.Polls.MaxOptions)
+	}
+}
+
+func TestGetInstanceV2Error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	_, err := client.GetInstanceV2(context.Background())
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+}
+
+func TestGetInstanceRules(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/instance/rules" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `[{"id": "1", "text": "Be nice", "hint": ""}, {"id": "2", "text": "No spam", "hint": "Including ads"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	rules, err := client.GetInstanceRules(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("result should be two: %d", len(rules))
+	}
+	if rules[1].ID != "2" {
+		t.Fatalf("want %q but %q", "2", rules[1].ID)
+	}
+	if rules[1].Hint != "Including ads" {
+		t.Fatalf("want %q but %q", "Including ads", rules[1].Hint)
+	}
+}
+
+func TestGetInstanceDocuments(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/instance/extended_description":
+			fmt.Fprintln(w, `{"updated_at": "2022-11-03T04:09:07Z", "content": "<p>About us</p>"}`)
+		case "/api/v1/instance/privacy_policy":
+			fmt.Fprintln(w, `{"updated_at": "2022-10-07T07:24:39Z", "content": "<p>Privacy</p>"}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	desc, err := client.GetInstanceExtendedDescription(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if desc.Content != "<p>About us</p>" {
+		t.Fatalf("want %q but %q", "<p>About us</p>", desc.Content)
+	}
+	if desc.UpdatedAt.Year() != 2022 {
+		t.Fatalf("want %v but %v", 2022, desc.UpdatedAt.Year())
+	}
+	policy, err := client.GetInstancePrivacyPolicy(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if policy.Content != "<p>Privacy</p>" {
+		t.Fatalf("want %q but %q", "<p>Privacy</p>", policy.Content)
+	}
+}
+
+func TestGetInstanceDomainBlocks(t *testing.T) {
+	canErr := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if canErr {
+			canErr = false
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `[{"domain": "bad.ex*****.com", "digest": "1ad5971df3f7234ff05a515fd3f8e2d076482c66bba5e42020bd52a6540e55b5", "severity": "suspend", "comment": "Spam"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	_, err := client.GetInstanceDomainBlocks(context.Background())
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	blocks, err := client.GetInstanceDomainBlocks(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("result should be one: %d", len(blocks))
+	}
+	if blocks[0].Severity != DomainBlockSuspend {
+		t.Fatalf("want %q but %q", DomainBlockSuspend, blocks[0].Severity)
+	}
+	if blocks[0].Comment != "Spam" {
+		t.Fatalf("want %q but %q", "Spam", blocks[0].Comment)
+	}
+	if !blocks[0].Matches("bad.example.com") {
+		t.Fatal("block should match bad.example.com")
+	}
+	if blocks[0].Matches("good.example.com") {
+		t.Fatal("block should not match good.example.com")
+	}
+}
+
+func TestGetInstanceLanguages(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/instance/languages" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `[{"code": "en", "name": "English"}, {"code": "sv", "name": "Swedish"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	languages, err := client.GetInstanceLanguages(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(languages) != 2 {
+		t.Fatalf("result should be two: %d", len(languages))
+	}
+	if languages[1].Code != "sv" || languages[1].Name != "Swedish" {
+		t.Fatalf("want %q but %q", "sv", languages[1].Code)
+	}
+}
@@ -0,0 +1,93 @@
+package masta
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Convenience constants for GetMarkers
+const (
+	MarkerHome          = "home"
+	MarkerNotifications = "notifications"
+)
+
+// Marker holds the read position of a timeline.
+type Marker struct {
+	LastReadID ID        `json:"last_read_id"`
+	Version    int64     `json:"version"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	Pleroma *struct {
+		UnreadCount int64 `json:"unread_count"`
+	} `json:"pleroma"`
+}
+
+// Markers holds the read positions of the home and notifications timelines.
+// A Marker is nil if it wasn't requested or hasn't been saved yet.
+type Markers struct {
+	Home          *Marker `json:"home"`
+	Notifications *Marker `json:"notifications"`
+}
+
+// GetMarkers returns the saved read positions of timelines, which are
+// MarkerHome and/or MarkerNotifications.
+func (c *Client) GetMarkers(ctx context.Context, timelines ...string) (*Markers, error) {
+	params := url.Values{}
+	for _, timeline := range timelines {
+		params.Add("timeline[]", timeline)
+	}
+
+	var markers Markers
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/markers", params, &markers, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &markers, nil
+}
+
+// SaveMarkers saves the read positions of the home and notifications
+// timelines. An empty ID leaves that timeline's marker unchanged.
+//
+// If another client saved a marker at the same time, an *APIError with
+// a Code of 409 is returned. UpdateMarkers handles this by retrying.
+func (c *Client) SaveMarkers(ctx context.Context, home, notifications ID) (*Markers, error) {
+	params := url.Values{}
+	stradd := addParamString(&params)
+	stradd("home[last_read_id]", home)
+	stradd("notifications[last_read_id]", notifications)
+
+	var markers Markers
+	err := c.doAPI(ctx, http.MethodPost, "/api/v1/markers", params, &markers, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &markers, nil
+}
+
+// UpdateMarkers fetches the current markers, passes them to update and
+// saves the IDs it returns. If saving conflicts with another client, the
+// markers are fetched again and update is called again, up to retries
+// times. This allows i.e. only moving the read position forward.
+func (c *Client) UpdateMarkers(ctx context.Context, retries int, update func(current *Markers) (home, notifications ID)) (*Markers, error) {
+	for i := 0; ; i++ {
+		current, err := c.GetMarkers(ctx, MarkerHome, MarkerNotifications)
+		if err != nil {
+			return nil, err
+		}
+
+		home, notifications := update(current)
+		if home == "" && notifications == "" {
+			return current, nil
+		}
+
+		markers, err := c.SaveMarkers(ctx, home, notifications)
+		var apiErr *APIError
+		if err != nil && i < retries && errors.As(err, &apiErr) && apiErr.Code == http.StatusConflict {
+			continue
+		}
+		return markers, err
+	}
+}
@@ -0,0 +1,132 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetMarkers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/markers" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		r.ParseForm()
+		if len(r.Form["timeline[]"]) != 2 {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, `{"home": {"last_read_id": "103194548672408537", "version": 462, "updated_at": "2019-11-24T19:39:39.337Z"}, "notifications": {"last_read_id": "35098814", "version": 361, "updated_at": "2019-11-26T22:37:25.239Z", "pleroma": {"unread_count": 7}}}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	_, err := client.GetMarkers(context.Background(), MarkerHome)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	markers, err := client.GetMarkers(context.Background(), MarkerHome, MarkerNotifications)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if markers.Home.LastReadID != "103194548672408537" {
+		t.Fatalf("want %q but %q", "103194548672408537", markers.Home.LastReadID)
+	}
+	if markers.Home.Version != 462 {
+		t.Fatalf("want %v but %v", 462, markers.Home.Version)
+	}
+	if markers.Notifications.Pleroma == nil || markers.Notifications.Pleroma.UnreadCount != 7 {
+		t.Fatalf("want %v unread notifications", 7)
+	}
+}
+
+func TestSaveMarkers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/markers" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		if r.PostFormValue("notifications[last_read_id]") != "" {
+			http.Error(w, "should not save notifications", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"home": {"last_read_id": %q, "version": 463}}`, r.PostFormValue("home[last_read_id]"))
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	markers, err := client.SaveMarkers(context.Background(), "42", "")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if markers.Home.LastReadID != "42" {
+		t.Fatalf("want %q but %q", "42", markers.Home.LastReadID)
+	}
+	if markers.Notifications != nil {
+		t.Fatal("notifications marker should be nil")
+	}
+}
+
+func TestUpdateMarkers(t *testing.T) {
+	conflicts := 2
+	gets := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/markers" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			gets++
+			fmt.Fprintf(w, `{"home": {"last_read_id": "%d"}}`, gets)
+			return
+		}
+		if conflicts > 0 {
+			conflicts--
+			http.Error(w, http.StatusText(http.StatusConflict), http.StatusConflict)
+			return
+		}
+		fmt.Fprintf(w, `{"home": {"last_read_id": %q}}`, r.PostFormValue("home[last_read_id]"))
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	update := func(current *Markers) (ID, ID) {
+		return current.Home.LastReadID + "0", ""
+	}
+
+	_, err := client.UpdateMarkers(context.Background(), 1, update)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+
+	conflicts = 2
+	gets = 0
+	markers, err := client.UpdateMarkers(context.Background(), 2, update)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if gets != 3 {
+		t.Fatalf("want %v but %v", 3, gets)
+	}
+	if markers.Home.LastReadID != "30" {
+		t.Fatalf("want %q but %q", "30", markers.Home.LastReadID)
+	}
+}
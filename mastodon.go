@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/tomnomnom/linkheader"
@@ -32,13 +33,32 @@ type Client struct {
 	http.Client
 	Config    *Config
 	UserAgent string
+
+	capsMu sync.Mutex
+	caps   *Capabilities
+}
+
+// multipartBody is implemented by request parameters that are sent as
+// multipart/form-data, such as Media.
+type multipartBody interface {
+	bodyAndContentType() (io.Reader, string, error)
 }
 
+// doAPI sends a request to the API and decodes the JSON response into res,
+// unless res is nil. Both 200 OK and 204 No Content are successful, and
+// nothing is decoded from a 204. If res is an io.Writer, the raw response
+// body is copied into it instead of being decoded. uri may have a query
+// string, which is kept for every method, for endpoints that read
+// parameters from the query string of requests with a body.
 func (c *Client) doAPI(ctx context.Context, method string, uri string, params interface{}, res interface{}, pg *Pagination) error {
 	u, err := url.Parse(c.Config.Server)
 	if err != nil {
 		return err
 	}
+	if i := strings.IndexByte(uri, '?'); i >= 0 {
+		u.RawQuery = uri[i+1:]
+		uri = uri[:i]
+	}
 	u.Path = path.Join(u.Path, uri)
 
 	var req *http.Request
@@ -49,7 +69,7 @@ func (c *Client) doAPI(ctx context.Context, method string, uri string, params in
 			if pg != nil {
 				values = pg.setValues(values)
 			}
-			u.RawQuery = values.Encode()
+			u.RawQuery = joinQuery(u.RawQuery, values.Encode())
 		} else {
 			body = strings.NewReader(values.Encode())
 		}
@@ -58,8 +78,8 @@ func (c *Client) doAPI(ctx context.Context, method string, uri string, params in
 		if err != nil {
 			return err
 		}
-	} else if media, ok := params.(*Media); ok {
-		r, contentType, err := media.bodyAndContentType()
+	} else if form, ok := params.(multipartBody); ok {
+		r, contentType, err := form.bodyAndContentType()
 		if err != nil {
 			return err
 		}
@@ -78,7 +98,7 @@ func (c *Client) doAPI(ctx context.Context, method string, uri string, params in
 		}
 	} else {
 		if method == http.MethodGet && pg != nil {
-			u.RawQuery = pg.toValues().Encode()
+			u.RawQuery = joinQuery(u.RawQuery, pg.toValues().Encode())
 		}
 		req, err = http.NewRequest(method, u.String(), nil)
 		if err != nil {
@@ -122,10 +142,14 @@ func (c *Client) doAPI(ctx context.Context, method string, uri string, params in
 		break
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
 		return parseAPIError("bad request", resp)
-	} else if res == nil {
+	} else if res == nil || resp.StatusCode == http.StatusNoContent {
 		return nil
+	} else if w, ok := res.(io.Writer); ok {
+		// Not every endpoint responds with JSON, e.g. instance documents.
+		_, err = io.Copy(w, resp.Body)
+		return err
 	} else if pg != nil {
 		if lh := resp.Header.Get("Link"); lh != "" {
 			pg2, err := newPagination(lh)
@@ -139,6 +163,13 @@ func (c *Client) doAPI(ctx context.Context, method string, uri string, params in
 	return json.NewDecoder(resp.Body).Decode(&res)
 }
 
+func joinQuery(a, b string) string {
+	if a == "" || b == "" {
+		return a + b
+	}
+	return a + "&" + b
+}
+
 // NewClient returns a new mastodon API client.
 func NewClient(config *Config) *Client {
 	return &Client{
@@ -279,9 +310,12 @@ type Mention struct {
 
 // Tag hold information for tag.
 type Tag struct {
-	Name    string    `json:"name"`
-	URL     string    `json:"url"`
-	History []History `json:"history"`
+	ID        ID        `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	History   []History `json:"history"`
+	Following bool      `json:"following"`
+	Featuring bool      `json:"featuring"`
 }
 
 // History hold information for history.
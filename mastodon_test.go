@@ -1,10 +1,12 @@
 package masta
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -89,6 +91,71 @@ func TestDoAPI(t *testing.T) {
 	}
 }
 
+func TestDoAPINoContent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/no-content":
+			w.WriteHeader(http.StatusNoContent)
+		case "/html":
+			fmt.Fprint(w, "<p>hello</p>")
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(&Config{Server: ts.URL})
+	var accounts []Account
+	err := c.doAPI(context.Background(), http.MethodPost, "/no-content", nil, &accounts, nil)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if accounts != nil {
+		t.Fatalf("want nil but %v", accounts)
+	}
+
+	var buf bytes.Buffer
+	err = c.doAPI(context.Background(), http.MethodGet, "/html", nil, &buf, nil)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if buf.String() != "<p>hello</p>" {
+		t.Fatalf("want %q but %q", "<p>hello</p>", buf.String())
+	}
+}
+
+func TestDoAPIQuery(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/foo" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		fmt.Fprintf(w, "%q", r.URL.RawQuery+" "+string(b))
+	}))
+	defer ts.Close()
+
+	c := NewClient(&Config{Server: ts.URL})
+	for _, tt := range []struct {
+		method string
+		params url.Values
+		want   string
+	}{
+		{http.MethodDelete, url.Values{"b": {"2"}}, "a=1 b=2"},
+		{http.MethodGet, url.Values{"b": {"2"}}, "a=1&b=2 "},
+		{http.MethodGet, nil, "a=1 "},
+	} {
+		var got string
+		err := c.doAPI(context.Background(), tt.method, "/api/v1/foo?a=1", tt.params, &got, nil)
+		if err != nil {
+			t.Fatalf("should not be fail: %v", err)
+		}
+		if got != tt.want {
+			t.Fatalf("want %q but %q", tt.want, got)
+		}
+	}
+}
+
 func TestAuthenticate(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.FormValue("username") != "valid" || r.FormValue("password") != "user" {
@@ -0,0 +1,125 @@
+package masta
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// NodeInfo schema identifiers, in order of preference.
+const (
+	NodeInfoSchema21 = "http://nodeinfo.diaspora.software/ns/schema/2.1"
+	NodeInfoSchema20 = "http://nodeinfo.diaspora.software/ns/schema/2.0"
+)
+
+// NodeInfo holds server metadata published through the NodeInfo protocol.
+type NodeInfo struct {
+	Version           string           `json:"version"`
+	Software          NodeInfoSoftware `json:"software"`
+	Protocols         []string         `json:"protocols"`
+	Services          NodeInfoServices `json:"services"`
+	OpenRegistrations bool             `json:"openRegistrations"`
+	Usage             NodeInfoUsage    `json:"usage"`
+	Metadata          NodeInfoMetadata `json:"metadata"`
+}
+
+// NodeInfoSoftware holds the name and version of the server software.
+// Repository and Homepage are only present in schema 2.1.
+type NodeInfoSoftware struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Repository string `json:"repository"`
+	Homepage   string `json:"homepage"`
+}
+
+// NodeInfoServices holds the third party sites the server can connect to.
+type NodeInfoServices struct {
+	Inbound  []string `json:"inbound"`
+	Outbound []string `json:"outbound"`
+}
+
+// NodeInfoUsage holds usage statistics for the server.
+type NodeInfoUsage struct {
+	Users struct {
+		Total          int64 `json:"total"`
+		ActiveMonth    int64 `json:"activeMonth"`
+		ActiveHalfyear int64 `json:"activeHalfyear"`
+	} `json:"users"`
+	LocalPosts    int64 `json:"localPosts"`
+	LocalComments int64 `json:"localComments"`
+}
+
+// NodeInfoMetadata holds the free-form metadata section. Only the commonly
+// used keys are decoded, Features and PostFormats are Pleroma extensions.
+type NodeInfoMetadata struct {
+	NodeName                  string   `json:"nodeName"`
+	NodeDescription           string   `json:"nodeDescription"`
+	Features                  []string `json:"features"`
+	PostFormats               []string `json:"postFormats"`
+	StaffAccounts             []string `json:"staffAccounts"`
+	AccountActivationRequired bool     `json:"accountActivationRequired"`
+}
+
+// GetNodeInfo fetches the NodeInfo document of host, preferring schema 2.1
+// over 2.0. host is a domain name such as "example.com", or a base URL if a
+// scheme other than https is needed. No authentication is required.
+func GetNodeInfo(ctx context.Context, host string) (*NodeInfo, error) {
+	return getNodeInfo(ctx, http.DefaultClient, "", host)
+}
+
+func getNodeInfo(ctx context.Context, client *http.Client, userAgent, host string) (*NodeInfo, error) {
+	u, err := hostURL(host)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "/.well-known/nodeinfo")
+
+	var links struct {
+		Links []struct {
+			Rel  string `json:"rel"`
+			Href string `json:"href"`
+		} `json:"links"`
+	}
+	if err := getJSON(ctx, client, userAgent, u.String(), &links); err != nil {
+		return nil, err
+	}
+
+	var href string
+	for _, link := range links.Links {
+		switch link.Rel {
+		case NodeInfoSchema21:
+			href = link.Href
+		case NodeInfoSchema20:
+			if href == "" {
+				href = link.Href
+			}
+		}
+	}
+	if href == "" {
+		return nil, errors.New("no supported nodeinfo schema")
+	}
+
+	var ni NodeInfo
+	if err := getJSON(ctx, client, userAgent, href, &ni); err != nil {
+		return nil, err
+	}
+	return &ni, nil
+}
+
+// hostURL turns a bare host, or a URL with a scheme, into a base URL.
+func hostURL(host string) (*url.URL, error) {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, errors.New("host can't be empty")
+	}
+	return u, nil
+}
@@ -0,0 +1,86 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetNodeInfo(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("nodeinfo should be fetched without authentication")
+		}
+		switch r.URL.Path {
+		case "/.well-known/nodeinfo":
+			fmt.Fprintf(w, `{"links": [{"rel": "http://nodeinfo.diaspora.software/ns/schema/2.0", "href": "%s/nodeinfo/2.0"}, {"rel": "http://nodeinfo.diaspora.software/ns/schema/2.1", "href": "%s/nodeinfo/2.1"}]}`, ts.URL, ts.URL)
+		case "/nodeinfo/2.1":
+			fmt.Fprintln(w, `{"version": "2.1", "software": {"name": "pleroma", "version": "2.5.2", "repository": "https://git.pleroma.social/pleroma/pleroma"}, "protocols": ["activitypub"], "services": {"inbound": [], "outbound": []}, "openRegistrations": true, "usage": {"users": {"total": 42, "activeMonth": 10, "activeHalfyear": 20}, "localPosts": 1234}, "metadata": {"nodeName": "Spiders", "features": ["pleroma_emoji_reactions", "editing"], "postFormats": ["text/plain", "text/markdown"]}}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ni, err := GetNodeInfo(context.Background(), ts.URL)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if ni.Version != "2.1" {
+		t.Fatalf("want %q but %q", "2.1", ni.Version)
+	}
+	if ni.Software.Name != "pleroma" {
+		t.Fatalf("want %q but %q", "pleroma", ni.Software.Name)
+	}
+	if ni.Software.Version != "2.5.2" {
+		t.Fatalf("want %q but %q", "2.5.2", ni.Software.Version)
+	}
+	if len(ni.Protocols) != 1 || ni.Protocols[0] != "activitypub" {
+		t.Fatalf("want %v but %v", []string{"activitypub"}, ni.Protocols)
+	}
+	if !ni.OpenRegistrations {
+		t.Fatal("want open registrations")
+	}
+	if ni.Usage.Users.Total != 42 {
+		t.Fatalf("want %d but %d", 42, ni.Usage.Users.Total)
+	}
+	if ni.Usage.Users.ActiveMonth != 10 {
+		t.Fatalf("want %d but %d", 10, ni.Usage.Users.ActiveMonth)
+	}
+	if ni.Usage.Users.ActiveHalfyear != 20 {
+		t.Fatalf("want %d but %d", 20, ni.Usage.Users.ActiveHalfyear)
+	}
+	if ni.Usage.LocalPosts != 1234 {
+		t.Fatalf("want %d but %d", 1234, ni.Usage.LocalPosts)
+	}
+	if ni.Metadata.NodeName != "Spiders" {
+		t.Fatalf("want %q but %q", "Spiders", ni.Metadata.NodeName)
+	}
+	if len(ni.Metadata.Features) != 2 {
+		t.Fatalf("result should be two: %d", len(ni.Metadata.Features))
+	}
+}
+
+func TestGetNodeInfoUnsupported(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/nodeinfo" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `{"links": [{"rel": "http://nodeinfo.diaspora.software/ns/schema/1.0", "href": "http://example.com/nodeinfo/1.0"}]}`)
+	}))
+	defer ts.Close()
+
+	_, err := GetNodeInfo(context.Background(), ts.URL)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+
+	_, err = GetNodeInfo(context.Background(), "")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+}
@@ -0,0 +1,433 @@
+package masta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PlAdminClient is a client for the Pleroma admin API. The access token must
+// have the admin scopes and belong to a moderator or administrator.
+type PlAdminClient struct {
+	client *Client
+}
+
+// PlAdmin returns a client for the Pleroma admin API.
+func (c *Client) PlAdmin() *PlAdminClient { return &PlAdminClient{client: c} }
+
+// do sends a request to the Pleroma admin API. params are sent as the query
+// string or form if they're url.Values, as multipart if they're a
+// multipartBody, and as JSON otherwise.
+func (p *PlAdminClient) do(ctx context.Context, method, uri string, params interface{}, res interface{}) error {
+	switch params.(type) {
+	case nil, url.Values, multipartBody:
+	default:
+		body, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		params = json.RawMessage(body)
+	}
+	return p.client.doAPI(ctx, method, "/api/v1/pleroma/admin"+uri, params, res, nil)
+}
+
+// Convenience constants for PlAdminUsersOpts.Filters
+const (
+	PlUserFilterLocal        = "local"
+	PlUserFilterExternal     = "external"
+	PlUserFilterActive       = "active"
+	PlUserFilterDeactivated  = "deactivated"
+	PlUserFilterNeedApproval = "need_approval"
+	PlUserFilterUnconfirmed  = "unconfirmed"
+	PlUserFilterIsAdmin      = "is_admin"
+	PlUserFilterIsModerator  = "is_moderator"
+)
+
+// Convenience constants for PlAdminClient.AddPermissionGroup
+const (
+	PlPermissionAdmin     = "admin"
+	PlPermissionModerator = "moderator"
+)
+
+// Convenience constants for PlAdminReport.State
+const (
+	PlReportOpen     = "open"
+	PlReportClosed   = "closed"
+	PlReportResolved = "resolved"
+)
+
+// PlAdminUser holds information for a user as seen by Pleroma admins.
+type PlAdminUser struct {
+	ID                 ID       `json:"id"`
+	Nickname           string   `json:"nickname"`
+	DisplayName        string   `json:"display_name"`
+	Avatar             string   `json:"avatar"`
+	URL                string   `json:"url"`
+	Email              string   `json:"email"`
+	Local              bool     `json:"local"`
+	IsActive           bool     `json:"is_active"`
+	IsConfirmed        bool     `json:"is_confirmed"`
+	IsApproved         bool     `json:"is_approved"`
+	RegistrationReason string   `json:"registration_reason"`
+	ActorType          string   `json:"actor_type"`
+	Tags               []string `json:"tags"`
+	Roles              struct {
+		Admin     bool `json:"admin"`
+		Moderator bool `json:"moderator"`
+	} `json:"roles"`
+}
+
+// PlAdminUsers holds a page of users.
+type PlAdminUsers struct {
+	Users    []*PlAdminUser `json:"users"`
+	Count    int64          `json:"count"`
+	PageSize int64          `json:"page_size"`
+}
+
+// PlAdminUsersOpts holds the filters for PlAdminClient.GetUsers.
+type PlAdminUsersOpts struct {
+	Query    string   // Search by nickname or display name.
+	Filters  []string // The PlUserFilter constants to apply.
+	Tags     []string // Only list users with these tags.
+	Name     string   // Search by display name.
+	Email    string   // Search by email address.
+	Page     int      // The page to return, starting at 1.
+	PageSize int      // The number of users per page.
+}
+
+func (o PlAdminUsersOpts) params() url.Values {
+	params := pageParams(o.Page, o.PageSize)
+
+	stradd := addParamString(&params)
+	stradd("query", o.Query)
+	stradd("filters", strings.Join(o.Filters, ","))
+	for _, tag := range o.Tags {
+		params.Add("tags[]", tag)
+	}
+	stradd("name", o.Name)
+	stradd("email", o.Email)
+
+	return params
+}
+
+type plNicknames struct {
+	Nicknames []string `json:"nicknames"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+// GetUsers returns a page of users matching opts.
+func (p *PlAdminClient) GetUsers(ctx context.Context, opts PlAdminUsersOpts) (*PlAdminUsers, error) {
+	var users PlAdminUsers
+	err := p.do(ctx, http.MethodGet, "/users", opts.params(), &users)
+	if err != nil {
+		return nil, err
+	}
+	return &users, nil
+}
+
+// GetUser returns a user by nickname or ID.
+func (p *PlAdminClient) GetUser(ctx context.Context, nicknameOrID string) (*PlAdminUser, error) {
+	var user PlAdminUser
+	err := p.do(ctx, http.MethodGet, "/users/"+url.PathEscape(nicknameOrID), nil, &user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (p *PlAdminClient) updateUsers(ctx context.Context, action string, nicknames []string) ([]*PlAdminUser, error) {
+	var res struct {
+		Users []*PlAdminUser `json:"users"`
+	}
+	err := p.do(ctx, http.MethodPatch, "/users/"+action, plNicknames{Nicknames: nicknames}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Users, nil
+}
+
+// ActivateUsers activates users.
+func (p *PlAdminClient) ActivateUsers(ctx context.Context, nicknames ...string) ([]*PlAdminUser, error) {
+	return p.updateUsers(ctx, "activate", nicknames)
+}
+
+// DeactivateUsers deactivates users.
+func (p *PlAdminClient) DeactivateUsers(ctx context.Context, nicknames ...string) ([]*PlAdminUser, error) {
+	return p.updateUsers(ctx, "deactivate", nicknames)
+}
+
+// ApproveUsers approves users waiting for approval.
+func (p *PlAdminClient) ApproveUsers(ctx context.Context, nicknames ...string) ([]*PlAdminUser, error) {
+	return p.updateUsers(ctx, "approve", nicknames)
+}
+
+// TagUsers adds tags to users, i.e. "mrf_tag:media-strip".
+func (p *PlAdminClient) TagUsers(ctx context.Context, nicknames, tags []string) error {
+	return p.do(ctx, http.MethodPut, "/users/tag", plNicknames{Nicknames: nicknames, Tags: tags}, nil)
+}
+
+// UntagUsers removes tags from users.
+func (p *PlAdminClient) UntagUsers(ctx context.Context, nicknames, tags []string) error {
+	return p.do(ctx, http.MethodDelete, "/users/tag", plNicknames{Nicknames: nicknames, Tags: tags}, nil)
+}
+
+// AddPermissionGroup adds users to PlPermissionAdmin or PlPermissionModerator.
+func (p *PlAdminClient) AddPermissionGroup(ctx context.Context, group string, nicknames ...string) error {
+	return p.do(ctx, http.MethodPost, "/users/permission_group/"+url.PathEscape(group), plNicknames{Nicknames: nicknames}, nil)
+}
+
+// RemovePermissionGroup removes users from a permission group.
+func (p *PlAdminClient) RemovePermissionGroup(ctx context.Context, group string, nicknames ...string) error {
+	return p.do(ctx, http.MethodDelete, "/users/permission_group/"+url.PathEscape(group), plNicknames{Nicknames: nicknames}, nil)
+}
+
+// ForcePasswordReset requires users to reset their password on next login.
+func (p *PlAdminClient) ForcePasswordReset(ctx context.Context, nicknames ...string) error {
+	return p.do(ctx, http.MethodPatch, "/users/force_password_reset", plNicknames{Nicknames: nicknames}, nil)
+}
+
+// DeleteUsers deletes users.
+func (p *PlAdminClient) DeleteUsers(ctx context.Context, nicknames ...string) error {
+	return p.do(ctx, http.MethodDelete, "/users", plNicknames{Nicknames: nicknames}, nil)
+}
+
+// PlAdminReport holds information for a report as seen by Pleroma admins.
+type PlAdminReport struct {
+	ID        ID                   `json:"id"`
+	State     string               `json:"state"`
+	Content   string               `json:"content"`
+	CreatedAt time.Time            `json:"created_at"`
+	Account   *PlAdminUser         `json:"account"`
+	Actor     *PlAdminUser         `json:"actor"`
+	Statuses  []*Status            `json:"statuses"`
+	Notes     []*PlAdminReportNote `json:"notes"`
+}
+
+// PlAdminReportNote holds a note left on a report by a moderator.
+type PlAdminReportNote struct {
+	ID        Sint         `json:"id"`
+	Content   string       `json:"content"`
+	UserID    ID           `json:"user_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	User      *PlAdminUser `json:"user"`
+}
+
+// PlAdminReports holds a page of reports.
+type PlAdminReports struct {
+	Total   int64            `json:"total"`
+	Reports []*PlAdminReport `json:"reports"`
+}
+
+// PlAdminReportUpdate changes the state of a report.
+type PlAdminReportUpdate struct {
+	ID    ID     `json:"id"`
+	State string `json:"state"`
+}
+
+// GetReports returns a page of reports in a state, or all reports if state
+// is empty.
+func (p *PlAdminClient) GetReports(ctx context.Context, state string, page, pageSize int) (*PlAdminReports, error) {
+	params := pageParams(page, pageSize)
+	stradd := addParamString(&params)
+	stradd("state", state)
+
+	var reports PlAdminReports
+	err := p.do(ctx, http.MethodGet, "/reports", params, &reports)
+	if err != nil {
+		return nil, err
+	}
+	return &reports, nil
+}
+
+// GetReport returns a report.
+func (p *PlAdminClient) GetReport(ctx context.Context, id ID) (*PlAdminReport, error) {
+	var report PlAdminReport
+	err := p.do(ctx, http.MethodGet, "/reports/"+url.PathEscape(string(id)), nil, &report)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// UpdateReports changes the state of reports.
+func (p *PlAdminClient) UpdateReports(ctx context.Context, updates ...PlAdminReportUpdate) error {
+	body := struct {
+		Reports []PlAdminReportUpdate `json:"reports"`
+	}{updates}
+	return p.do(ctx, http.MethodPatch, "/reports", body, nil)
+}
+
+// AddReportNote leaves a note on a report for other moderators.
+func (p *PlAdminClient) AddReportNote(ctx context.Context, reportID ID, content string) error {
+	body := struct {
+		Content string `json:"content"`
+	}{content}
+	return p.do(ctx, http.MethodPost, fmt.Sprintf("/reports/%s/notes", url.PathEscape(string(reportID))), body, nil)
+}
+
+// DeleteReportNote deletes a note from a report.
+func (p *PlAdminClient) DeleteReportNote(ctx context.Context, reportID ID, noteID Sint) error {
+	return p.do(ctx, http.MethodDelete, fmt.Sprintf("/reports/%s/notes/%d", url.PathEscape(string(reportID)), noteID), nil, nil)
+}
+
+// PlAdminStatusesOpts holds the filters for PlAdminClient.GetStatuses.
+type PlAdminStatusesOpts struct {
+	Godmode     bool // Include private and direct statuses.
+	LocalOnly   bool // Exclude remote statuses.
+	WithReblogs bool // Include reblogs.
+	Page        int  // The page to return, starting at 1.
+	PageSize    int  // The number of statuses per page.
+}
+
+func (o PlAdminStatusesOpts) params() url.Values {
+	params := pageParams(o.Page, o.PageSize)
+
+	badd := addParamBool(&params)
+	badd("godmode", o.Godmode)
+	badd("local_only", o.LocalOnly)
+	badd("with_reblogs", o.WithReblogs)
+
+	return params
+}
+
+// GetStatuses returns a page of statuses on the instance.
+func (p *PlAdminClient) GetStatuses(ctx context.Context, opts PlAdminStatusesOpts) ([]*Status, error) {
+	var statuses []*Status
+	err := p.do(ctx, http.MethodGet, "/statuses", opts.params(), &statuses)
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+func (p *PlAdminClient) updateStatus(ctx context.Context, id ID, body interface{}) (*Status, error) {
+	var status Status
+	err := p.do(ctx, http.MethodPut, "/statuses/"+url.PathEscape(string(id)), body, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ChangeStatusVisibility changes the visibility of a status.
+func (p *PlAdminClient) ChangeStatusVisibility(ctx context.Context, id ID, visibility string) (*Status, error) {
+	return p.updateStatus(ctx, id, struct {
+		Visibility string `json:"visibility"`
+	}{visibility})
+}
+
+// SetStatusSensitive marks the media of a status as sensitive or not.
+func (p *PlAdminClient) SetStatusSensitive(ctx context.Context, id ID, sensitive bool) (*Status, error) {
+	return p.updateStatus(ctx, id, struct {
+		Sensitive bool `json:"sensitive"`
+	}{sensitive})
+}
+
+// DeleteStatus deletes a status.
+func (p *PlAdminClient) DeleteStatus(ctx context.Context, id ID) error {
+	return p.do(ctx, http.MethodDelete, "/statuses/"+url.PathEscape(string(id)), nil, nil)
+}
+
+// PlRelay holds a relay the instance follows.
+type PlRelay struct {
+	Actor        string `json:"actor"`
+	FollowedBack bool   `json:"followed_back"`
+}
+
+// GetRelays returns the relays the instance follows.
+func (p *PlAdminClient) GetRelays(ctx context.Context) ([]PlRelay, error) {
+	var res struct {
+		Relays []PlRelay `json:"relays"`
+	}
+	err := p.do(ctx, http.MethodGet, "/relay", nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Relays, nil
+}
+
+type plRelayURL struct {
+	RelayURL string `json:"relay_url"`
+	Force    bool   `json:"force,omitempty"`
+}
+
+// FollowRelay follows the relay with the given actor URL.
+func (p *PlAdminClient) FollowRelay(ctx context.Context, relayURL string) error {
+	return p.do(ctx, http.MethodPost, "/relay", plRelayURL{RelayURL: relayURL}, nil)
+}
+
+// UnfollowRelay unfollows a relay. If force is true, the relay is removed
+// even if it can't be reached.
+func (p *PlAdminClient) UnfollowRelay(ctx context.Context, relayURL string, force bool) error {
+	return p.do(ctx, http.MethodDelete, "/relay", plRelayURL{RelayURL: relayURL, Force: force}, nil)
+}
+
+// PlInvite holds an invite token. ExpiresAt is a date in the form of
+// "2006-01-02", or empty if the invite doesn't expire.
+type PlInvite struct {
+	ID         Sint   `json:"id"`
+	Token      string `json:"token"`
+	Used       bool   `json:"used"`
+	ExpiresAt  string `json:"expires_at"`
+	Uses       int64  `json:"uses"`
+	MaxUse     int64  `json:"max_use"`
+	InviteType string `json:"invite_type"`
+}
+
+// GetInvites returns the invite tokens.
+func (p *PlAdminClient) GetInvites(ctx context.Context) ([]*PlInvite, error) {
+	var res struct {
+		Invites []*PlInvite `json:"invites"`
+	}
+	err := p.do(ctx, http.MethodGet, "/users/invites", nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Invites, nil
+}
+
+// CreateInvite creates an invite token. A zero maxUse or expiresAt doesn't
+// limit the invite by uses or by time.
+func (p *PlAdminClient) CreateInvite(ctx context.Context, maxUse int, expiresAt time.Time) (*PlInvite, error) {
+	body := struct {
+		MaxUse    int    `json:"max_use,omitempty"`
+		ExpiresAt string `json:"expires_at,omitempty"`
+	}{MaxUse: maxUse}
+	if !expiresAt.IsZero() {
+		body.ExpiresAt = expiresAt.Format("2006-01-02")
+	}
+
+	var invite PlInvite
+	err := p.do(ctx, http.MethodPost, "/users/invite_token", body, &invite)
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// RevokeInvite revokes an invite token.
+func (p *PlAdminClient) RevokeInvite(ctx context.Context, token string) (*PlInvite, error) {
+	body := struct {
+		Token string `json:"token"`
+	}{token}
+
+	var invite PlInvite
+	err := p.do(ctx, http.MethodPost, "/users/revoke_invite", body, &invite)
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// EmailInvite sends an invite to an email address. name is optional.
+func (p *PlAdminClient) EmailInvite(ctx context.Context, email, name string) error {
+	body := struct {
+		Email string `json:"email"`
+		Name  string `json:"name,omitempty"`
+	}{email, name}
+	return p.do(ctx, http.MethodPost, "/users/email_invite", body, nil)
+}
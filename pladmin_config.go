@@ -0,0 +1,195 @@
+package masta
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// Convenience constants for PlAdminClient.GetInstanceDocument
+const (
+	PlDocumentInstancePanel  = "instance-panel"
+	PlDocumentTermsOfService = "terms-of-service"
+)
+
+// GetInstanceDocument returns the HTML of an instance document.
+func (p *PlAdminClient) GetInstanceDocument(ctx context.Context, name string) (string, error) {
+	var buf bytes.Buffer
+	err := p.do(ctx, http.MethodGet, "/instance_document/"+url.PathEscape(name), nil, &buf)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// UpdateInstanceDocument replaces an instance document with the HTML read
+// from r, and returns the URL it's served at.
+func (p *PlAdminClient) UpdateInstanceDocument(ctx context.Context, name string, r io.Reader) (string, error) {
+	form := &multipartForm{files: []multipartFile{{field: "file", file: r}}}
+
+	var res struct {
+		URL string `json:"url"`
+	}
+	err := p.do(ctx, http.MethodPatch, "/instance_document/"+url.PathEscape(name), form, &res)
+	if err != nil {
+		return "", err
+	}
+	return res.URL, nil
+}
+
+// DeleteInstanceDocument resets an instance document to its default.
+func (p *PlAdminClient) DeleteInstanceDocument(ctx context.Context, name string) error {
+	return p.do(ctx, http.MethodDelete, "/instance_document/"+url.PathEscape(name), nil, nil)
+}
+
+// PlAdminConfig holds a setting stored in the database, i.e. the group
+// ":pleroma" and key ":instance". Value is in Pleroma's JSON encoding of
+// Elixir terms, in which keyword lists are lists of {"tuple": [key, value]}
+// objects. Use Keywords to decode it.
+type PlAdminConfig struct {
+	Group string          `json:"group"`
+	Key   string          `json:"key"`
+	DB    []string        `json:"db"`
+	Value json.RawMessage `json:"value"`
+}
+
+// PlAdminConfigs holds the settings stored in the database.
+type PlAdminConfigs struct {
+	Configs    []*PlAdminConfig `json:"configs"`
+	NeedReboot bool             `json:"need_reboot"`
+}
+
+// PlAdminConfigUpdate changes a setting. Value is encoded as JSON, so keyword
+// lists must be built with PlKeywordList or PlConfigTuple. If Delete is true,
+// the setting is removed from the database, or only the keys in Subkeys if
+// it's non-empty.
+type PlAdminConfigUpdate struct {
+	Group   string      `json:"group"`
+	Key     string      `json:"key"`
+	Value   interface{} `json:"value,omitempty"`
+	Delete  bool        `json:"delete,omitempty"`
+	Subkeys []string    `json:"subkeys,omitempty"`
+}
+
+// PlConfigTuple returns a keyword list entry in Pleroma's encoding.
+func PlConfigTuple(key string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{"tuple": []interface{}{key, value}}
+}
+
+// PlKeywordList converts a map into a keyword list in Pleroma's encoding,
+// sorted by key. Nested maps are converted as well.
+func PlKeywordList(m map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	list := make([]interface{}, 0, len(m))
+	for _, key := range keys {
+		value := m[key]
+		if nested, ok := value.(map[string]interface{}); ok {
+			value = PlKeywordList(nested)
+		}
+		list = append(list, PlConfigTuple(key, value))
+	}
+	return list
+}
+
+// Keywords decodes Value as a keyword list into a map. Nested keyword lists
+// are decoded into maps as well.
+func (c *PlAdminConfig) Keywords() (map[string]interface{}, error) {
+	var value interface{}
+	if err := json.Unmarshal(c.Value, &value); err != nil {
+		return nil, err
+	}
+	m, ok := fromKeywordList(value).(map[string]interface{})
+	if !ok {
+		return nil, errors.New("value is not a keyword list")
+	}
+	return m, nil
+}
+
+// configTuple returns the key and value of a keyword list entry.
+func configTuple(v interface{}) (string, interface{}, bool) {
+	obj, ok := v.(map[string]interface{})
+	if !ok || len(obj) != 1 {
+		return "", nil, false
+	}
+	tuple, ok := obj["tuple"].([]interface{})
+	if !ok || len(tuple) != 2 {
+		return "", nil, false
+	}
+	key, ok := tuple[0].(string)
+	return key, tuple[1], ok
+}
+
+func fromKeywordList(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		m := map[string]interface{}{}
+		for _, entry := range v {
+			key, value, ok := configTuple(entry)
+			if !ok {
+				m = nil
+				break
+			}
+			m[key] = fromKeywordList(value)
+		}
+		if len(v) > 0 && m != nil {
+			return m
+		}
+
+		list := make([]interface{}, len(v))
+		for i, entry := range v {
+			list[i] = fromKeywordList(entry)
+		}
+		return list
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[key] = fromKeywordList(value)
+		}
+		return m
+	default:
+		return v
+	}
+}
+
+// GetConfig returns the settings. If onlyDB is true, only the settings
+// stored in the database are returned, rather than all the settings in
+// effect.
+func (p *PlAdminClient) GetConfig(ctx context.Context, onlyDB bool) (*PlAdminConfigs, error) {
+	params := url.Values{}
+	if onlyDB {
+		params.Set("only_db", "true")
+	}
+
+	var configs PlAdminConfigs
+	err := p.do(ctx, http.MethodGet, "/config", params, &configs)
+	if err != nil {
+		return nil, err
+	}
+	return &configs, nil
+}
+
+// UpdateConfig changes settings, and returns the changed settings. Some
+// settings only take effect after a restart, which is indicated by
+// PlAdminConfigs.NeedReboot.
+func (p *PlAdminClient) UpdateConfig(ctx context.Context, updates ...PlAdminConfigUpdate) (*PlAdminConfigs, error) {
+	body := struct {
+		Configs []PlAdminConfigUpdate `json:"configs"`
+	}{updates}
+
+	var configs PlAdminConfigs
+	err := p.do(ctx, http.MethodPost, "/config", body, &configs)
+	if err != nil {
+		return nil, err
+	}
+	return &configs, nil
+}
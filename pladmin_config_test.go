@@ -0,0 +1,179 @@
+package masta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlAdminInstanceDocument(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/pleroma/admin/instance_document/instance-panel":
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, "<h1>Welcome</h1>")
+		case "PATCH /api/v1/pleroma/admin/instance_document/terms-of-service":
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			b, _ := io.ReadAll(file)
+			if string(b) != "<p>Be nice</p>" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `{"url": "https://example.com/static/terms-of-service.html"}`)
+		case "DELETE /api/v1/pleroma/admin/instance_document/terms-of-service":
+			fmt.Fprintln(w, `{"url": "https://example.com/static/terms-of-service.html"}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.PlAdmin()
+	ctx := context.Background()
+
+	doc, err := admin.GetInstanceDocument(ctx, PlDocumentInstancePanel)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if doc != "<h1>Welcome</h1>" {
+		t.Fatalf("want %q but %q", "<h1>Welcome</h1>", doc)
+	}
+	_, err = admin.GetInstanceDocument(ctx, PlDocumentTermsOfService)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+
+	u, err := admin.UpdateInstanceDocument(ctx, PlDocumentTermsOfService, strings.NewReader("<p>Be nice</p>"))
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if u != "https://example.com/static/terms-of-service.html" {
+		t.Fatalf("want %q but %q", "https://example.com/static/terms-of-service.html", u)
+	}
+	if err := admin.DeleteInstanceDocument(ctx, PlDocumentTermsOfService); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+}
+
+func TestPlAdminConfig(t *testing.T) {
+	var updated string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/pleroma/admin/config":
+			if r.URL.Query().Get("only_db") != "true" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `{"configs": [{"group": ":pleroma", "key": ":instance", "db": [":name", ":limit"], "value": [{"tuple": [":name", "Spiders"]}, {"tuple": [":limit", 5000]}, {"tuple": [":poll_limits", [{"tuple": [":max_options", 20]}]]}, {"tuple": [":languages", ["en", "de"]]}]}, {"group": ":pleroma", "key": "Pleroma.Captcha", "db": [], "value": ["not", "a", "keyword", "list"]}], "need_reboot": false}`)
+		case "POST /api/v1/pleroma/admin/config":
+			b, _ := io.ReadAll(r.Body)
+			updated = string(b)
+			fmt.Fprintln(w, `{"configs": [{"group": ":pleroma", "key": ":instance", "db": [":name"], "value": [{"tuple": [":name", "Webs"]}]}], "need_reboot": true}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.PlAdmin()
+	ctx := context.Background()
+
+	_, err := admin.GetConfig(ctx, false)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	configs, err := admin.GetConfig(ctx, true)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(configs.Configs) != 2 {
+		t.Fatalf("result should be two: %d", len(configs.Configs))
+	}
+	kw, err := configs.Configs[0].Keywords()
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	want := map[string]interface{}{
+		":name":        "Spiders",
+		":limit":       float64(5000),
+		":poll_limits": map[string]interface{}{":max_options": float64(20)},
+		":languages":   []interface{}{"en", "de"},
+	}
+	if !reflect.DeepEqual(kw, want) {
+		t.Fatalf("want %v but %v", want, kw)
+	}
+	_, err = configs.Configs[1].Keywords()
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+
+	configs, err = admin.UpdateConfig(ctx, PlAdminConfigUpdate{
+		Group: ":pleroma",
+		Key:   ":instance",
+		Value: PlKeywordList(map[string]interface{}{
+			":name":        "Webs",
+			":poll_limits": map[string]interface{}{":max_options": 10},
+		}),
+	}, PlAdminConfigUpdate{
+		Group:   ":pleroma",
+		Key:     ":instance",
+		Delete:  true,
+		Subkeys: []string{":limit"},
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if !configs.NeedReboot {
+		t.Fatalf("want %v but %v", true, configs.NeedReboot)
+	}
+	wantBody := `{"configs":[{"group":":pleroma","key":":instance","value":[{"tuple":[":name","Webs"]},{"tuple":[":poll_limits",[{"tuple":[":max_options",10]}]]}]},{"group":":pleroma","key":":instance","delete":true,"subkeys":[":limit"]}]}`
+	if updated != wantBody {
+		t.Fatalf("want %s but %s", wantBody, updated)
+	}
+}
+
+func TestPlConfigTuple(t *testing.T) {
+	b, err := json.Marshal(PlConfigTuple(":enabled", true))
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if string(b) != `{"tuple":[":enabled",true]}` {
+		t.Fatalf("want %s but %s", `{"tuple":[":enabled",true]}`, b)
+	}
+
+	c := &PlAdminConfig{Value: json.RawMessage(`[]`)}
+	if _, err := c.Keywords(); err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	c.Value = json.RawMessage(`[{"tuple": [":a", [{"tuple": [":b", {"c": [{"tuple": [":d", 1]}]}]}]]}]`)
+	kw, err := c.Keywords()
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	want := map[string]interface{}{":a": map[string]interface{}{":b": map[string]interface{}{"c": map[string]interface{}{":d": float64(1)}}}}
+	if !reflect.DeepEqual(kw, want) {
+		t.Fatalf("want %v but %v", want, kw)
+	}
+}
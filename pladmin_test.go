@@ -0,0 +1,410 @@
+package masta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// decodePlAdminBody decodes the JSON body of a Pleroma admin request.
+func decodePlAdminBody(r *http.Request) map[string]interface{} {
+	var body map[string]interface{}
+	if r.Header.Get("Content-Type") != "application/json" {
+		return nil
+	}
+	json.NewDecoder(r.Body).Decode(&body)
+	return body
+}
+
+func TestPlAdminUsers(t *testing.T) {
+	var last map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = decodePlAdminBody(r)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/pleroma/admin/users":
+			q := r.URL.Query()
+			if q.Get("query") != "foo" || q.Get("filters") != "local,is_admin" || q.Get("page") != "2" || q.Get("page_size") != "10" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `{"users": [{"id": "1", "nickname": "foo", "is_active": true, "local": true, "roles": {"admin": true, "moderator": false}, "tags": ["mrf_tag:media-strip"]}], "count": 11, "page_size": 10}`)
+		case "GET /api/v1/pleroma/admin/users/foo":
+			fmt.Fprintln(w, `{"id": "1", "nickname": "foo", "email": "foo@example.com"}`)
+		case "PATCH /api/v1/pleroma/admin/users/activate":
+			fmt.Fprintln(w, `{"users": [{"nickname": "foo", "is_active": true}, {"nickname": "bar", "is_active": true}]}`)
+		case "PATCH /api/v1/pleroma/admin/users/deactivate", "PATCH /api/v1/pleroma/admin/users/approve":
+			fmt.Fprintln(w, `{"users": [{"nickname": "foo", "is_active": false, "is_approved": true}]}`)
+		case "PUT /api/v1/pleroma/admin/users/tag", "DELETE /api/v1/pleroma/admin/users/tag":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /api/v1/pleroma/admin/users/permission_group/moderator", "DELETE /api/v1/pleroma/admin/users/permission_group/moderator":
+			fmt.Fprintln(w, `{"is_moderator": true}`)
+		case "PATCH /api/v1/pleroma/admin/users/force_password_reset", "DELETE /api/v1/pleroma/admin/users":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.PlAdmin()
+	ctx := context.Background()
+
+	users, err := admin.GetUsers(ctx, PlAdminUsersOpts{
+		Query:    "foo",
+		Filters:  []string{PlUserFilterLocal, PlUserFilterIsAdmin},
+		Page:     2,
+		PageSize: 10,
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if users.Count != 11 || len(users.Users) != 1 {
+		t.Fatalf("want one of 11 users but %v", users)
+	}
+	if !users.Users[0].Roles.Admin || !users.Users[0].IsActive || len(users.Users[0].Tags) != 1 {
+		t.Fatalf("want active admin but %v", users.Users[0])
+	}
+
+	user, err := admin.GetUser(ctx, "foo")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if user.Email != "foo@example.com" {
+		t.Fatalf("want %q but %q", "foo@example.com", user.Email)
+	}
+	_, err = admin.GetUser(ctx, "bar")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+
+	activated, err := admin.ActivateUsers(ctx, "foo", "bar")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(activated) != 2 {
+		t.Fatalf("result should be two: %d", len(activated))
+	}
+	if fmt.Sprint(last["nicknames"]) != "[foo bar]" {
+		t.Fatalf("want %v but %v", []string{"foo", "bar"}, last["nicknames"])
+	}
+	deactivated, err := admin.DeactivateUsers(ctx, "foo")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if deactivated[0].IsActive {
+		t.Fatalf("want %v but %v", false, deactivated[0].IsActive)
+	}
+	approved, err := admin.ApproveUsers(ctx, "foo")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if !approved[0].IsApproved {
+		t.Fatalf("want %v but %v", true, approved[0].IsApproved)
+	}
+
+	if err := admin.TagUsers(ctx, []string{"foo"}, []string{"mrf_tag:disable-any-subscription"}); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if fmt.Sprint(last["tags"]) != "[mrf_tag:disable-any-subscription]" {
+		t.Fatalf("want tags but %v", last)
+	}
+	if err := admin.UntagUsers(ctx, []string{"foo"}, []string{"mrf_tag:disable-any-subscription"}); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if err := admin.AddPermissionGroup(ctx, PlPermissionModerator, "foo"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if err := admin.RemovePermissionGroup(ctx, PlPermissionModerator, "foo"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if err := admin.AddPermissionGroup(ctx, PlPermissionAdmin, "foo"); err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	if err := admin.ForcePasswordReset(ctx, "foo"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if err := admin.DeleteUsers(ctx, "foo"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if fmt.Sprint(last["nicknames"]) != "[foo]" {
+		t.Fatalf("want %v but %v", []string{"foo"}, last["nicknames"])
+	}
+}
+
+func TestPlAdminReports(t *testing.T) {
+	var last map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = decodePlAdminBody(r)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/pleroma/admin/reports":
+			if r.URL.Query().Get("state") != "open" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `{"total": 1, "reports": [{"id": "1", "state": "open", "content": "spam", "account": {"id": "2", "nickname": "spammer"}, "actor": {"id": "3", "nickname": "foo"}, "statuses": [{"id": "4"}], "notes": []}]}`)
+		case "GET /api/v1/pleroma/admin/reports/1":
+			fmt.Fprintln(w, `{"id": "1", "state": "open", "notes": [{"id": 5, "content": "looking into it", "user_id": "6", "user": {"nickname": "mod"}}]}`)
+		case "PATCH /api/v1/pleroma/admin/reports", "POST /api/v1/pleroma/admin/reports/1/notes", "DELETE /api/v1/pleroma/admin/reports/1/notes/5":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.PlAdmin()
+	ctx := context.Background()
+
+	_, err := admin.GetReports(ctx, "", 0, 0)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	reports, err := admin.GetReports(ctx, PlReportOpen, 1, 20)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if reports.Total != 1 || len(reports.Reports) != 1 {
+		t.Fatalf("want one report but %v", reports)
+	}
+	if reports.Reports[0].Account.Nickname != "spammer" || len(reports.Reports[0].Statuses) != 1 {
+		t.Fatalf("want report on %q but %v", "spammer", reports.Reports[0])
+	}
+
+	report, err := admin.GetReport(ctx, "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(report.Notes) != 1 || report.Notes[0].ID != 5 || report.Notes[0].User.Nickname != "mod" {
+		t.Fatalf("want one note but %v", report.Notes)
+	}
+
+	if err := admin.UpdateReports(ctx, PlAdminReportUpdate{ID: "1", State: PlReportResolved}); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if fmt.Sprint(last["reports"]) != "[map[id:1 state:resolved]]" {
+		t.Fatalf("want reports but %v", last)
+	}
+	if err := admin.AddReportNote(ctx, "1", "done"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if last["content"] != "done" {
+		t.Fatalf("want %q but %v", "done", last["content"])
+	}
+	if err := admin.DeleteReportNote(ctx, "1", report.Notes[0].ID); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+}
+
+func TestPlAdminStatuses(t *testing.T) {
+	var last map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = decodePlAdminBody(r)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/pleroma/admin/statuses":
+			q := r.URL.Query()
+			if q.Get("godmode") != "true" || q.Get("local_only") != "" || q.Get("page_size") != "5" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `[{"id": "1", "visibility": "direct"}]`)
+		case "PUT /api/v1/pleroma/admin/statuses/1":
+			visibility, _ := last["visibility"].(string)
+			if visibility == "" {
+				visibility = "public"
+			}
+			sensitive, _ := last["sensitive"].(bool)
+			fmt.Fprintf(w, `{"id": "1", "visibility": %q, "sensitive": %v}`, visibility, sensitive)
+		case "DELETE /api/v1/pleroma/admin/statuses/1":
+			fmt.Fprintln(w, `{}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.PlAdmin()
+	ctx := context.Background()
+
+	statuses, err := admin.GetStatuses(ctx, PlAdminStatusesOpts{Godmode: true, PageSize: 5})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].Visibility != "direct" {
+		t.Fatalf("want one direct status but %v", statuses)
+	}
+
+	status, err := admin.ChangeStatusVisibility(ctx, "1", "unlisted")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if status.Visibility != "unlisted" {
+		t.Fatalf("want %q but %q", "unlisted", status.Visibility)
+	}
+	if _, ok := last["sensitive"]; ok {
+		t.Fatalf("sensitive should not be set: %v", last)
+	}
+	status, err = admin.SetStatusSensitive(ctx, "1", false)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if sensitive, ok := last["sensitive"]; !ok || sensitive != false {
+		t.Fatalf("want sensitive false but %v", last)
+	}
+	if status.Sensitive {
+		t.Fatalf("want %v but %v", false, status.Sensitive)
+	}
+
+	if err := admin.DeleteStatus(ctx, "1"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if err := admin.DeleteStatus(ctx, "2"); err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+}
+
+func TestPlAdminRelays(t *testing.T) {
+	var last map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = decodePlAdminBody(r)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/pleroma/admin/relay":
+			fmt.Fprintln(w, `{"relays": [{"actor": "https://relay.example.com/actor", "followed_back": true}]}`)
+		case "POST /api/v1/pleroma/admin/relay", "DELETE /api/v1/pleroma/admin/relay":
+			fmt.Fprintf(w, "%q\n", last["relay_url"])
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.PlAdmin()
+	ctx := context.Background()
+
+	relays, err := admin.GetRelays(ctx)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(relays) != 1 || !relays[0].FollowedBack {
+		t.Fatalf("want one relay but %v", relays)
+	}
+	if err := admin.FollowRelay(ctx, "https://relay.example.com/actor"); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if last["relay_url"] != "https://relay.example.com/actor" {
+		t.Fatalf("want %q but %v", "https://relay.example.com/actor", last["relay_url"])
+	}
+	if _, ok := last["force"]; ok {
+		t.Fatalf("force should not be set: %v", last)
+	}
+	if err := admin.UnfollowRelay(ctx, "https://relay.example.com/actor", true); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if last["force"] != true {
+		t.Fatalf("want force but %v", last)
+	}
+}
+
+func TestPlAdminInvites(t *testing.T) {
+	var last map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = decodePlAdminBody(r)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/pleroma/admin/users/invites":
+			fmt.Fprintln(w, `{"invites": [{"id": 123, "token": "kSQtDj_GNy2NZsL9AQDFIsHN5qdbguB6qRg3WHw6K1U=", "used": true, "expires_at": "2019-04-02", "uses": 1, "max_use": 1, "invite_type": "one_time"}]}`)
+		case "POST /api/v1/pleroma/admin/users/invite_token":
+			expiresAt, _ := json.Marshal(last["expires_at"])
+			fmt.Fprintf(w, `{"id": 124, "token": "abc", "used": false, "expires_at": %s, "uses": 0, "max_use": 5, "invite_type": "reusable_date_limited"}`, expiresAt)
+		case "POST /api/v1/pleroma/admin/users/revoke_invite":
+			fmt.Fprintf(w, `{"id": 124, "token": %q, "used": true}`, last["token"])
+		case "POST /api/v1/pleroma/admin/users/email_invite":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	admin := client.PlAdmin()
+	ctx := context.Background()
+
+	invites, err := admin.GetInvites(ctx)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(invites) != 1 {
+		t.Fatalf("result should be one: %d", len(invites))
+	}
+	if invites[0].ID != 123 || invites[0].InviteType != "one_time" {
+		t.Fatalf("want invite 123 but %v", invites[0])
+	}
+
+	invite, err := admin.CreateInvite(ctx, 5, time.Date(2022, 9, 20, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if invite.ExpiresAt != "2022-09-20" || invite.MaxUse != 5 {
+		t.Fatalf("want invite until %q but %v", "2022-09-20", invite)
+	}
+	if last["max_use"] != float64(5) {
+		t.Fatalf("want max_use 5 but %v", last)
+	}
+	_, err = admin.CreateInvite(ctx, 0, time.Time{})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(last) != 0 {
+		t.Fatalf("want empty body but %v", last)
+	}
+
+	invite, err = admin.RevokeInvite(ctx, "abc")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if invite.Token != "abc" || !invite.Used {
+		t.Fatalf("want revoked invite %q but %v", "abc", invite)
+	}
+
+	if err := admin.EmailInvite(ctx, "foo@example.com", ""); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if last["email"] != "foo@example.com" {
+		t.Fatalf("want email but %v", last)
+	}
+	if _, ok := last["name"]; ok {
+		t.Fatalf("name should not be set: %v", last)
+	}
+}
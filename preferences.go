@@ -0,0 +1,49 @@
+package masta
+
+import (
+	"context"
+	"net/http"
+)
+
+// Convenience constants for Preferences.ReadingExpandMedia
+const (
+	ExpandMediaDefault = "default"
+	ExpandMediaShowAll = "show_all"
+	ExpandMediaHideAll = "hide_all"
+)
+
+// Preferences holds the current user's preferences.
+type Preferences struct {
+	PostingDefaultVisibility string `json:"posting:default:visibility"`
+	PostingDefaultSensitive  bool   `json:"posting:default:sensitive"`
+	PostingDefaultLanguage   string `json:"posting:default:language"`
+	ReadingExpandMedia       string `json:"reading:expand:media"`
+	ReadingExpandSpoilers    bool   `json:"reading:expand:spoilers"`
+}
+
+// GetPreferences returns the current user's preferences.
+func (c *Client) GetPreferences(ctx context.Context) (*Preferences, error) {
+	var prefs Preferences
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/preferences", nil, &prefs, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// ApplyDefaults fills in the visibility and language of toot with the
+// user's posting defaults if they're empty. If the user marks media as
+// sensitive by default and toot has media, it's marked as sensitive; since
+// a false Toot.Sensitive can't be told apart from an unset one, it's never
+// turned off.
+func (p *Preferences) ApplyDefaults(toot *Toot) {
+	if toot.Visibility == "" {
+		toot.Visibility = p.PostingDefaultVisibility
+	}
+	if toot.Language == "" {
+		toot.Language = p.PostingDefaultLanguage
+	}
+	if p.PostingDefaultSensitive && len(toot.MediaIDs) > 0 {
+		toot.Sensitive = true
+	}
+}
@@ -0,0 +1,78 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetPreferences(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/preferences" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `{"posting:default:visibility": "unlisted", "posting:default:sensitive": true, "posting:default:language": null, "reading:expand:media": "show_all", "reading:expand:spoilers": true}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	prefs, err := client.GetPreferences(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if prefs.PostingDefaultVisibility != VisibilityUnlisted {
+		t.Fatalf("want %q but %q", VisibilityUnlisted, prefs.PostingDefaultVisibility)
+	}
+	if !prefs.PostingDefaultSensitive {
+		t.Fatal("want sensitive by default")
+	}
+	if prefs.PostingDefaultLanguage != "" {
+		t.Fatalf("want %q but %q", "", prefs.PostingDefaultLanguage)
+	}
+	if prefs.ReadingExpandMedia != ExpandMediaShowAll {
+		t.Fatalf("want %q but %q", ExpandMediaShowAll, prefs.ReadingExpandMedia)
+	}
+	if !prefs.ReadingExpandSpoilers {
+		t.Fatal("want spoilers expanded")
+	}
+}
+
+func TestPreferencesApplyDefaults(t *testing.T) {
+	prefs := &Preferences{
+		PostingDefaultVisibility: VisibilityFollowersOnly,
+		PostingDefaultSensitive:  true,
+		PostingDefaultLanguage:   "sv",
+	}
+
+	toot := &Toot{Status: "foo"}
+	prefs.ApplyDefaults(toot)
+	if toot.Visibility != VisibilityFollowersOnly {
+		t.Fatalf("want %q but %q", VisibilityFollowersOnly, toot.Visibility)
+	}
+	if toot.Language != "sv" {
+		t.Fatalf("want %q but %q", "sv", toot.Language)
+	}
+	if toot.Sensitive {
+		t.Fatal("toot without media should not be sensitive")
+	}
+
+	toot = &Toot{Status: "foo", Visibility: VisibilityDirectMessage, Language: "en", MediaIDs: []ID{"1"}}
+	prefs.ApplyDefaults(toot)
+	if toot.Visibility != VisibilityDirectMessage {
+		t.Fatalf("want %q but %q", VisibilityDirectMessage, toot.Visibility)
+	}
+	if toot.Language != "en" {
+		t.Fatalf("want %q but %q", "en", toot.Language)
+	}
+	if !toot.Sensitive {
+		t.Fatal("toot with media should be sensitive")
+	}
+}
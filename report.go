@@ -4,12 +4,55 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"time"
+)
+
+// Convenience constants for ReportOpts.Category
+const (
+	ReportCategorySpam      = "spam"
+	ReportCategoryViolation = "violation"
+	ReportCategoryLegal     = "legal"
+	ReportCategoryOther     = "other"
 )
 
 // Report holds information for a mastodon report.
 type Report struct {
-	ID          int64 `json:"id"`
-	ActionTaken bool  `json:"action_taken"`
+	ID            ID        `json:"id"`
+	ActionTaken   bool      `json:"action_taken"`
+	ActionTakenAt time.Time `json:"action_taken_at"`
+	Category      string    `json:"category"`
+	Comment       string    `json:"comment"`
+	Forwarded     bool      `json:"forwarded"`
+	CreatedAt     time.Time `json:"created_at"`
+	StatusIDs     []ID      `json:"status_ids"`
+	RuleIDs       []ID      `json:"rule_ids"`
+	TargetAccount *Account  `json:"target_account"`
+}
+
+// ReportOpts holds the optional parameters of a report.
+type ReportOpts struct {
+	StatusIDs []ID   // Statuses to attach to the report.
+	Comment   string // The reason for the report.
+	Forward   bool   // Forward the report to the remote instance of the account.
+	Category  string // One of the ReportCategory constants.
+	RuleIDs   []ID   // IDs of the InstanceRules violated, for ReportCategoryViolation.
+}
+
+func (r ReportOpts) params() url.Values {
+	params := &url.Values{}
+
+	stradd, badd := addParamFuncs(params)
+	for _, id := range r.StatusIDs {
+		params.Add("status_ids[]", string(id))
+	}
+	stradd("comment", r.Comment)
+	badd("forward", r.Forward)
+	stradd("category", r.Category)
+	for _, id := range r.RuleIDs {
+		params.Add("rule_ids[]", string(id))
+	}
+
+	return *params
 }
 
 // GetReports returns report of the current user.
@@ -24,12 +67,13 @@ func (c *Client) GetReports(ctx context.Context) ([]*Report, error) {
 
 // Report reports the report
 func (c *Client) Report(ctx context.Context, accountID ID, ids []ID, comment string) (*Report, error) {
-	params := url.Values{}
+	return c.ReportWith(ctx, accountID, ReportOpts{StatusIDs: ids, Comment: comment})
+}
+
+// ReportWith reports an account to the moderators, with extra options.
+func (c *Client) ReportWith(ctx context.Context, accountID ID, opts ReportOpts) (*Report, error) {
+	params := opts.params()
 	params.Set("account_id", string(accountID))
-	for _, id := range ids {
-		params.Add("status_ids[]", string(id))
-	}
-	params.Set("comment", comment)
 	var report Report
 	err := c.doAPI(ctx, http.MethodPost, "/api/v1/reports", params, &report, nil)
 	if err != nil {
@@ -2,6 +2,7 @@ package masta
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -14,7 +15,7 @@ func TestGetReports(t *testing.T) {
 			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 			return
 		}
-		fmt.Fprintln(w, `[{"id": 122, "action_taken": false}, {"id": 123, "action_taken": true}]`)
+		fmt.Fprintln(w, `[{"id": "122", "action_taken": false, "category": "spam", "status_ids": null, "rule_ids": null, "target_account": {"id": "1", "acct": "spammer"}}, {"id": "123", "action_taken": true, "action_taken_at": "2022-09-20T17:27:39.296Z", "category": "violation", "status_ids": ["4"], "rule_ids": ["2"], "target_account": {"id": "2"}}]`)
 	}))
 	defer ts.Close()
 
@@ -31,11 +32,26 @@ func TestGetReports(t *testing.T) {
 	if len(rs) != 2 {
 		t.Fatalf("result should be two: %d", len(rs))
 	}
-	if rs[0].ID != 122 {
-		t.Fatalf("want %v but %v", 122, rs[0].ID)
+	if rs[0].ID != "122" {
+		t.Fatalf("want %q but %q", "122", rs[0].ID)
 	}
-	if rs[1].ID != 123 {
-		t.Fatalf("want %v but %v", 123, rs[1].ID)
+	if rs[0].TargetAccount.Acct != "spammer" {
+		t.Fatalf("want %q but %q", "spammer", rs[0].TargetAccount.Acct)
+	}
+	if rs[1].ID != "123" {
+		t.Fatalf("want %q but %q", "123", rs[1].ID)
+	}
+	if rs[1].Category != ReportCategoryViolation {
+		t.Fatalf("want %q but %q", ReportCategoryViolation, rs[1].Category)
+	}
+	if rs[1].ActionTakenAt.IsZero() {
+		t.Fatal("action_taken_at should not be zero")
+	}
+	if len(rs[1].StatusIDs) != 1 || rs[1].StatusIDs[0] != "4" {
+		t.Fatalf("want %v but %v", []ID{"4"}, rs[1].StatusIDs)
+	}
+	if len(rs[1].RuleIDs) != 1 || rs[1].RuleIDs[0] != "2" {
+		t.Fatalf("want %v but %v", []ID{"2"}, rs[1].RuleIDs)
 	}
 }
 
@@ -50,9 +66,9 @@ func TestReport(t *testing.T) {
 			return
 		}
 		if r.FormValue("account_id") == "122" {
-			fmt.Fprintln(w, `{"id": 1234, "action_taken": false}`)
+			fmt.Fprintln(w, `{"id": "1234", "action_taken": false}`)
 		} else {
-			fmt.Fprintln(w, `{"id": 1234, "action_taken": true}`)
+			fmt.Fprintln(w, `{"id": "1234", "action_taken": true}`)
 		}
 	}))
 	defer ts.Close()
@@ -71,7 +87,7 @@ func TestReport(t *testing.T) {
 	if err != nil {
 		t.Fatalf("should not be fail: %v", err)
 	}
-	if rp.ID != 1234 {
+	if rp.ID != "1234" {
 		t.Fatalf("want %q but %q", "1234", rp.ID)
 	}
 	if rp.ActionTaken {
@@ -81,10 +97,64 @@ func TestReport(t *testing.T) {
 	if err != nil {
 		t.Fatalf("should not be fail: %v", err)
 	}
-	if rp.ID != 1234 {
+	if rp.ID != "1234" {
 		t.Fatalf("want %q but %q", "1234", rp.ID)
 	}
 	if !rp.ActionTaken {
 		t.Fatalf("want %v but %v", false, rp.ActionTaken)
 	}
 }
+
+func TestReportWith(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/reports" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		r.ParseForm()
+		if r.PostFormValue("account_id") != "122" || r.PostFormValue("forward") != "true" {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		if _, ok := r.PostForm["comment"]; ok {
+			http.Error(w, "comment should not be set", http.StatusBadRequest)
+			return
+		}
+		statusIDs, _ := json.Marshal(r.PostForm["status_ids[]"])
+		ruleIDs, _ := json.Marshal(r.PostForm["rule_ids[]"])
+		fmt.Fprintf(w, `{"id": "1234", "category": %q, "forwarded": true, "status_ids": %s, "rule_ids": %s}`, r.PostFormValue("category"), statusIDs, ruleIDs)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	_, err := client.ReportWith(context.Background(), "121", ReportOpts{Forward: true})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	rp, err := client.ReportWith(context.Background(), "122", ReportOpts{
+		StatusIDs: []ID{"4", "5"},
+		Forward:   true,
+		Category:  ReportCategoryViolation,
+		RuleIDs:   []ID{"1", "3"},
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if rp.Category != ReportCategoryViolation {
+		t.Fatalf("want %q but %q", ReportCategoryViolation, rp.Category)
+	}
+	if !rp.Forwarded {
+		t.Fatalf("want %v but %v", true, rp.Forwarded)
+	}
+	if len(rp.StatusIDs) != 2 || rp.StatusIDs[1] != "5" {
+		t.Fatalf("want %v but %v", []ID{"4", "5"}, rp.StatusIDs)
+	}
+	if len(rp.RuleIDs) != 2 || rp.RuleIDs[1] != "3" {
+		t.Fatalf("want %v but %v", []ID{"1", "3"}, rp.RuleIDs)
+	}
+}
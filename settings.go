@@ -0,0 +1,100 @@
+package masta
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// PlFrontendSettings decodes the settings a frontend stored on the account
+// into v. The settings store is only sent for the current user, by
+// GetAccountCurrentUser and AccountUpdate. It returns false if the
+// frontend has no settings.
+func (a *Account) PlFrontendSettings(frontend string, v interface{}) (bool, error) {
+	if a.Pleroma == nil || a.Pleroma.SettingsStore == nil {
+		return false, nil
+	}
+	settings, ok := (*a.Pleroma.SettingsStore)[frontend]
+	if !ok || settings == nil {
+		return false, nil
+	}
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(b, v)
+}
+
+// PlUpdateFrontendSettings replaces the settings of a frontend with
+// settings, which must marshal to a JSON object. The settings of other
+// frontends are kept.
+func (c *Client) PlUpdateFrontendSettings(ctx context.Context, frontend string, settings interface{}) (*Account, error) {
+	return c.AccountUpdate(ctx, &Profile{
+		SettingsStore: map[string]interface{}{frontend: settings},
+	})
+}
+
+// PlMergeFrontendSettings merges settings, which must marshal to a JSON
+// object, into the stored settings of a frontend. Nested objects are merged
+// recursively, other values replace the stored ones.
+func (c *Client) PlMergeFrontendSettings(ctx context.Context, frontend string, settings interface{}) (*Account, error) {
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+	var update map[string]interface{}
+	if err := json.Unmarshal(b, &update); err != nil {
+		return nil, err
+	}
+
+	account, err := c.GetAccountCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var stored map[string]interface{}
+	if _, err := account.PlFrontendSettings(frontend, &stored); err != nil {
+		return nil, err
+	}
+	return c.PlUpdateFrontendSettings(ctx, frontend, mergeSettings(stored, update))
+}
+
+func mergeSettings(dst, src map[string]interface{}) map[string]interface{} {
+	if dst == nil {
+		dst = map[string]interface{}{}
+	}
+	for k, v := range src {
+		if srcMap, ok := v.(map[string]interface{}); ok {
+			if dstMap, ok := dst[k].(map[string]interface{}); ok {
+				dst[k] = mergeSettings(dstMap, srcMap)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+	return dst
+}
+
+// PlFrontendConfigurations holds the instance-wide configuration of each
+// frontend, by frontend name, i.e. "pleroma_fe" or "masto_fe".
+type PlFrontendConfigurations map[string]json.RawMessage
+
+// Decode decodes the configuration of a frontend into v. It returns false
+// if the frontend isn't configured.
+func (f PlFrontendConfigurations) Decode(frontend string, v interface{}) (bool, error) {
+	config, ok := f[frontend]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(config, v)
+}
+
+// PlGetFrontendConfigurations returns the frontend configurations of the
+// instance.
+func (c *Client) PlGetFrontendConfigurations(ctx context.Context) (PlFrontendConfigurations, error) {
+	var configs PlFrontendConfigurations
+	err := c.doAPI(ctx, http.MethodGet, "/api/pleroma/frontend_configurations", nil, &configs, nil)
+	if err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
@@ -0,0 +1,135 @@
+package masta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testFrontendSettings struct {
+	Theme   string          `json:"theme"`
+	Columns []string        `json:"columns"`
+	Notify  map[string]bool `json:"notify"`
+}
+
+func TestPlFrontendSettings(t *testing.T) {
+	// Pleroma merges the frontends sent into the stored settings.
+	store := map[string]interface{}{
+		"pleroma_fe": map[string]interface{}{"theme": "pleroma-dark"},
+		"masta":      map[string]interface{}{"theme": "light", "columns": []string{"home"}, "notify": map[string]bool{"mention": true, "follow": true}},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/accounts/verify_credentials":
+		case "PATCH /api/v1/accounts/update_credentials":
+			if r.Header.Get("Content-Type") != "application/json" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			var body struct {
+				Store map[string]interface{} `json:"pleroma_settings_store"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			for k, v := range body.Store {
+				store[k] = v
+			}
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"username": "foo",
+			"pleroma":  map[string]interface{}{"settings_store": store},
+		})
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	ctx := context.Background()
+
+	account, err := client.PlMergeFrontendSettings(ctx, "masta", map[string]interface{}{
+		"columns": []string{"home", "local"},
+		"notify":  map[string]bool{"follow": false},
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	var settings testFrontendSettings
+	ok, err := account.PlFrontendSettings("masta", &settings)
+	if err != nil || !ok {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if settings.Theme != "light" || len(settings.Columns) != 2 || !settings.Notify["mention"] || settings.Notify["follow"] {
+		t.Fatalf("want merged settings but %#v", settings)
+	}
+	var other map[string]interface{}
+	if ok, _ := account.PlFrontendSettings("pleroma_fe", &other); !ok || other["theme"] != "pleroma-dark" {
+		t.Fatalf("want pleroma_fe settings kept but %v", other)
+	}
+
+	account, err = client.PlUpdateFrontendSettings(ctx, "masta", testFrontendSettings{Theme: "dark"})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	settings = testFrontendSettings{}
+	if _, err := account.PlFrontendSettings("masta", &settings); err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if settings.Theme != "dark" || len(settings.Columns) != 0 {
+		t.Fatalf("want replaced settings but %#v", settings)
+	}
+
+	if ok, err := account.PlFrontendSettings("soapbox_fe", &settings); ok || err != nil {
+		t.Fatalf("want no settings but %v %v", ok, err)
+	}
+	if ok, err := (&Account{}).PlFrontendSettings("masta", &settings); ok || err != nil {
+		t.Fatalf("want no settings but %v %v", ok, err)
+	}
+}
+
+func TestPlGetFrontendConfigurations(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/pleroma/frontend_configurations" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `{"pleroma_fe": {"theme": "pleroma-dark", "showInstanceSpecificPanel": true}, "masto_fe": {"showInstanceSpecificPanel": false}}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	configs, err := client.PlGetFrontendConfigurations(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	var pleromaFE struct {
+		Theme         string `json:"theme"`
+		InstancePanel bool   `json:"showInstanceSpecificPanel"`
+	}
+	ok, err := configs.Decode("pleroma_fe", &pleromaFE)
+	if err != nil || !ok {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if pleromaFE.Theme != "pleroma-dark" || !pleromaFE.InstancePanel {
+		t.Fatalf("want pleroma-dark but %#v", pleromaFE)
+	}
+	if ok, _ := configs.Decode("soapbox_fe", &pleromaFE); ok {
+		t.Fatal("want soapbox_fe not configured")
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -17,35 +20,36 @@ import (
 
 // Status is struct to hold status.
 type Status struct {
-	ID                 ID           `json:"id"`
-	URI                string       `json:"uri"`
-	URL                string       `json:"url"`
-	Account            Account      `json:"account"`
-	InReplyToID        *ID          `json:"in_reply_to_id"`
-	InReplyToAccountID *ID          `json:"in_reply_to_account_id"`
-	Reblog             *Status      `json:"reblog"`
-	Content            string       `json:"content"`
-	CreatedAt          time.Time    `json:"created_at"`
-	EditedAt           time.Time    `json:"edited_at"`
-	Emojis             []Emoji      `json:"emojis"`
-	RepliesCount       int64        `json:"replies_count"`
-	ReblogsCount       int64        `json:"reblogs_count"`
-	FavouritesCount    int64        `json:"favourites_count"`
-	Reblogged          bool         `json:"reblogged"`
-	Favourited         bool         `json:"favourited"`
-	Bookmarked         bool         `json:"bookmarked"`
-	Muted              bool         `json:"muted"`
-	Sensitive          bool         `json:"sensitive"`
-	SpoilerText        string       `json:"spoiler_text"`
-	Visibility         string       `json:"visibility"`
-	MediaAttachments   []Attachment `json:"media_attachments"`
-	Mentions           []Mention    `json:"mentions"`
-	Tags               []Tag        `json:"tags"`
-	Card               *Card        `json:"card"`
-	Poll               *Poll        `json:"poll"`
-	Application        Application  `json:"application"`
-	Language           string       `json:"language"`
-	Pinned             bool         `json:"pinned"`
+	ID                 ID             `json:"id"`
+	URI                string         `json:"uri"`
+	URL                string         `json:"url"`
+	Account            Account        `json:"account"`
+	InReplyToID        *ID            `json:"in_reply_to_id"`
+	InReplyToAccountID *ID            `json:"in_reply_to_account_id"`
+	Reblog             *Status        `json:"reblog"`
+	Content            string         `json:"content"`
+	CreatedAt          time.Time      `json:"created_at"`
+	EditedAt           time.Time      `json:"edited_at"`
+	Emojis             []Emoji        `json:"emojis"`
+	RepliesCount       int64          `json:"replies_count"`
+	ReblogsCount       int64          `json:"reblogs_count"`
+	FavouritesCount    int64          `json:"favourites_count"`
+	Reblogged          bool           `json:"reblogged"`
+	Favourited         bool           `json:"favourited"`
+	Bookmarked         bool           `json:"bookmarked"`
+	Muted              bool           `json:"muted"`
+	Sensitive          bool           `json:"sensitive"`
+	SpoilerText        string         `json:"spoiler_text"`
+	Visibility         string         `json:"visibility"`
+	MediaAttachments   []Attachment   `json:"media_attachments"`
+	Mentions           []Mention      `json:"mentions"`
+	Tags               []Tag          `json:"tags"`
+	Card               *Card          `json:"card"`
+	Poll               *Poll          `json:"poll"`
+	Application        Application    `json:"application"`
+	Language           string         `json:"language"`
+	Pinned             bool           `json:"pinned"`
+	Filtered           []FilterResult `json:"filtered"`
 
 	Pleroma *struct {
 		DirectConversationID int64             `json:"direct_conversation_id"`
@@ -59,11 +63,30 @@ type Status struct {
 	} `json:"pleroma,omitempty"`
 }
 
+// FilterAction returns FilterActionHide if any of the filters in
+// Status.Filtered hide the status, FilterActionWarn if any of them warn
+// about it, and an empty string if none matched.
+func (s *Status) FilterAction() string {
+	action := ""
+	for _, result := range s.Filtered {
+		switch result.Filter.FilterAction {
+		case FilterActionHide:
+			return FilterActionHide
+		case FilterActionWarn:
+			action = FilterActionWarn
+		}
+	}
+	return action
+}
+
+// EmojiReaction holds a Pleroma emoji reaction to a status. URL is only set
+// for custom emoji.
 type EmojiReaction struct {
 	Accounts []*Account `json:"accounts"`
 	Emoji    string     `json:"name"`
 	Count    int64      `json:"count"`
 	Me       bool       `json:"me"`
+	URL      string     `json:"url"`
 }
 
 // StatusHistory is a struct to hold status history data.
@@ -128,52 +151,71 @@ func (m *Media) bodyAndContentType() (io.Reader, string, error) {
 	var buf bytes.Buffer
 	mw := multipart.NewWriter(&buf)
 
-	fileName := "upload"
-	if f, ok := m.File.(*os.File); ok {
-		fileName = f.Name()
-	}
-	file, err := mw.CreateFormFile("file", fileName)
-	if err != nil {
-		return nil, "", err
-	}
-	if _, err := io.Copy(file, m.File); err != nil {
+	if err := writeFormFile(mw, "file", m.File); err != nil {
 		return nil, "", err
 	}
 
 	if m.Thumbnail != nil {
-		thumbName := "upload"
-		if f, ok := m.Thumbnail.(*os.File); ok {
-			thumbName = f.Name()
-		}
-		thumb, err := mw.CreateFormFile("thumbnail", thumbName)
-		if err != nil {
-			return nil, "", err
-		}
-		if _, err := io.Copy(thumb, m.Thumbnail); err != nil {
+		if err := writeFormFile(mw, "thumbnail", m.Thumbnail); err != nil {
 			return nil, "", err
 		}
 	}
 
 	if m.Description != "" {
-		desc, err := mw.CreateFormField("description")
-		if err != nil {
-			return nil, "", err
-		}
-		if _, err := io.Copy(desc, strings.NewReader(m.Description)); err != nil {
+		if err := writeFormField(mw, "description", m.Description); err != nil {
 			return nil, "", err
 		}
 	}
 
 	if m.Focus != "" {
-		focus, err := mw.CreateFormField("focus")
-		if err != nil {
+		if err := writeFormField(mw, "focus", m.Focus); err != nil {
 			return nil, "", err
 		}
-		if _, err := io.Copy(focus, strings.NewReader(m.Focus)); err != nil {
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return &buf, mw.FormDataContentType(), nil
+}
+
+// multipartForm is a multipart/form-data request body made of regular
+// values and files, for endpoints that accept uploads alongside other
+// parameters.
+type multipartForm struct {
+	values url.Values
+	files  []multipartFile
+}
+
+type multipartFile struct {
+	field string
+	file  io.Reader
+}
+
+func (f *multipartForm) bodyAndContentType() (io.Reader, string, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	for _, file := range f.files {
+		if err := writeFormFile(mw, file.field, file.file); err != nil {
 			return nil, "", err
 		}
 	}
 
+	keys := make([]string, 0, len(f.values))
+	for key := range f.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range f.values[key] {
+			if err := writeFormField(mw, key, value); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
 	if err := mw.Close(); err != nil {
 		return nil, "", err
 	}
@@ -181,6 +223,28 @@ func (m *Media) bodyAndContentType() (io.Reader, string, error) {
 	return &buf, mw.FormDataContentType(), nil
 }
 
+func writeFormFile(mw *multipart.Writer, field string, r io.Reader) error {
+	fileName := "upload"
+	if f, ok := r.(*os.File); ok {
+		fileName = f.Name()
+	}
+	file, err := mw.CreateFormFile(field, fileName)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, r)
+	return err
+}
+
+func writeFormField(mw *multipart.Writer, field, value string) error {
+	w, err := mw.CreateFormField(field)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, strings.NewReader(value))
+	return err
+}
+
 // GetFavourites returns the favorite list of the current user.
 func (c *Client) GetFavourites(ctx context.Context, pg *Pagination) ([]*Status, error) {
 	var statuses []*Status
@@ -281,6 +345,63 @@ func (c *Client) PlGetReactions(ctx context.Context, id ID, withmuted bool) (rea
 	return
 }
 
+var shortcodeRe = regexp.MustCompile(`^[A-Za-z0-9_+-]+(@[A-Za-z0-9.-]+)?$`)
+
+// reactionEmoji wraps custom emoji shortcodes in colons, so that "blobcat"
+// and "blobcat@example.com" (a remote emoji on Akkoma) may be passed as well
+// as ":blobcat:". Unicode emoji are returned as is.
+func reactionEmoji(emoji string) string {
+	emoji = strings.TrimSpace(emoji)
+	if shortcodeRe.MatchString(emoji) {
+		return ":" + emoji + ":"
+	}
+	return emoji
+}
+
+func (c *Client) plReaction(ctx context.Context, method string, id ID, emoji string, res interface{}) error {
+	if emoji == "" {
+		return errors.New("emoji can't be empty")
+	}
+	return c.doAPI(ctx, method, fmt.Sprintf("/api/v1/pleroma/statuses/%s/reactions/%s", url.PathEscape(string(id)), reactionEmoji(emoji)), nil, res, nil)
+}
+
+// PlReact reacts to a status with a unicode emoji or a custom emoji
+// shortcode, with or without colons. On Akkoma, remote custom emoji can be
+// used in the form of "name@host".
+func (c *Client) PlReact(ctx context.Context, id ID, emoji string) (*Status, error) {
+	var status Status
+	err := c.plReaction(ctx, http.MethodPut, id, emoji, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// PlUnreact removes a reaction from a status.
+func (c *Client) PlUnreact(ctx context.Context, id ID, emoji string) (*Status, error) {
+	var status Status
+	err := c.plReaction(ctx, http.MethodDelete, id, emoji, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// PlGetReactionsBy returns the accounts that reacted to a status with emoji.
+func (c *Client) PlGetReactionsBy(ctx context.Context, id ID, emoji string) ([]*Account, error) {
+	var reactions []EmojiReaction
+	err := c.plReaction(ctx, http.MethodGet, id, emoji, &reactions)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []*Account
+	for _, reaction := range reactions {
+		accounts = append(accounts, reaction.Accounts...)
+	}
+	return accounts, nil
+}
+
 // Reblog reblogs the toot of id and returns status of reblog.
 func (c *Client) Reblog(ctx context.Context, id ID) (*Status, error) {
 	var status Status
@@ -729,7 +729,7 @@ func TestSearch(t *testing.T) {
 		t.Fatalf("Hashtags have %q entries, but %q", "3", len(ret.Hashtags))
 	}
 	if ret.Hashtags[2].Name != "tag3" {
-		t.Fatalf("Hashtags[2] should %q , but %q", "tag3", ret.Hashtags[2])
+		t.Fatalf("Hashtags[2] should %q , but %q", "tag3", ret.Hashtags[2].Name)
 	}
 }
 
@@ -868,3 +868,110 @@ func TestMarkConversationsAsRead(t *testing.T) {
 		t.Fatalf("should not be fail: %v", err)
 	}
 }
+
+func TestPlReact(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /api/v1/pleroma/statuses/1/reactions/👍":
+			fmt.Fprintln(w, `{"id": "1", "pleroma": {"emoji_reactions": [{"name": "👍", "count": 1, "me": true}]}}`)
+		case "PUT /api/v1/pleroma/statuses/1/reactions/:blobcat:":
+			fmt.Fprintln(w, `{"id": "1", "pleroma": {"emoji_reactions": [{"name": "blobcat", "count": 1, "me": true, "url": "https://example.com/emoji/blobcat.png"}]}}`)
+		case "PUT /api/v1/pleroma/statuses/1/reactions/:blobcat@example.com:":
+			fmt.Fprintln(w, `{"id": "1", "pleroma": {"emoji_reactions": [{"name": "blobcat@example.com", "count": 1, "me": true}]}}`)
+		case "DELETE /api/v1/pleroma/statuses/1/reactions/:blobcat:":
+			fmt.Fprintln(w, `{"id": "1", "pleroma": {"emoji_reactions": []}}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	ctx := context.Background()
+
+	_, err := client.PlReact(ctx, "1", "")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	status, err := client.PlReact(ctx, "1", "👍")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(status.Pleroma.EmojiReactions) != 1 || status.Pleroma.EmojiReactions[0].Emoji != "👍" {
+		t.Fatalf("want reaction %q but %v", "👍", status.Pleroma.EmojiReactions)
+	}
+	for _, emoji := range []string{"blobcat", ":blobcat:", " blobcat "} {
+		status, err = client.PlReact(ctx, "1", emoji)
+		if err != nil {
+			t.Fatalf("should not be fail: %v", err)
+		}
+		if status.Pleroma.EmojiReactions[0].URL != "https://example.com/emoji/blobcat.png" {
+			t.Fatalf("want %q but %q", "https://example.com/emoji/blobcat.png", status.Pleroma.EmojiReactions[0].URL)
+		}
+	}
+	for _, emoji := range []string{"blobcat@example.com", ":blobcat@example.com:"} {
+		status, err = client.PlReact(ctx, "1", emoji)
+		if err != nil {
+			t.Fatalf("should not be fail: %v", err)
+		}
+		if status.Pleroma.EmojiReactions[0].Emoji != "blobcat@example.com" {
+			t.Fatalf("want %q but %q", "blobcat@example.com", status.Pleroma.EmojiReactions[0].Emoji)
+		}
+	}
+
+	status, err = client.PlUnreact(ctx, "1", "blobcat")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(status.Pleroma.EmojiReactions) != 0 {
+		t.Fatalf("result should be zero: %d", len(status.Pleroma.EmojiReactions))
+	}
+	_, err = client.PlUnreact(ctx, "1", "👍")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+}
+
+func TestPlGetReactionsBy(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/pleroma/statuses/1/reactions/👍":
+			fmt.Fprintln(w, `[{"name": "👍", "count": 2, "me": false, "accounts": [{"id": "2", "acct": "foo"}, {"id": "3", "acct": "bar"}]}]`)
+		case "/api/v1/pleroma/statuses/1/reactions/:blobcat:":
+			fmt.Fprintln(w, `[]`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	accounts, err := client.PlGetReactionsBy(context.Background(), "1", "👍")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(accounts) != 2 || accounts[1].Acct != "bar" {
+		t.Fatalf("want two accounts but %v", accounts)
+	}
+	accounts, err = client.PlGetReactionsBy(context.Background(), "1", "blobcat")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(accounts) != 0 {
+		t.Fatalf("result should be zero: %d", len(accounts))
+	}
+	_, err = client.PlGetReactionsBy(context.Background(), "2", "👍")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+}
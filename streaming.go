@@ -39,6 +39,32 @@ type DeleteEvent struct{ ID ID }
 
 func (e *DeleteEvent) event() {}
 
+// AnnouncementEvent is a struct for passing announcement event to app.
+type AnnouncementEvent struct {
+	Announcement *Announcement `json:"announcement"`
+}
+
+func (e *AnnouncementEvent) event() {}
+
+// AnnouncementReactionEvent is a struct for passing announcement reaction event to app.
+type AnnouncementReactionEvent struct {
+	Reaction *AnnouncementReaction `json:"reaction"`
+}
+
+func (e *AnnouncementReactionEvent) event() {}
+
+// AnnouncementDeleteEvent is a struct for passing announcement deletion event to app.
+type AnnouncementDeleteEvent struct{ ID ID }
+
+func (e *AnnouncementDeleteEvent) event() {}
+
+// ChatUpdateEvent is a struct for passing Pleroma chat update event to app.
+type ChatUpdateEvent struct {
+	Chat *Chat `json:"chat"`
+}
+
+func (e *ChatUpdateEvent) event() {}
+
 // ErrorEvent is a struct for passing errors to app.
 type ErrorEvent struct{ err error }
 
@@ -102,6 +128,26 @@ func handleReader(q chan Event, r io.Reader) error {
 				}
 			case "delete":
 				q <- &DeleteEvent{ID: ID(strings.TrimSpace(token[1]))}
+			case "announcement":
+				var announcement Announcement
+				err = json.Unmarshal([]byte(token[1]), &announcement)
+				if err == nil {
+					q <- &AnnouncementEvent{&announcement}
+				}
+			case "announcement.reaction":
+				var reaction AnnouncementReaction
+				err = json.Unmarshal([]byte(token[1]), &reaction)
+				if err == nil {
+					q <- &AnnouncementReactionEvent{&reaction}
+				}
+			case "announcement.delete":
+				q <- &AnnouncementDeleteEvent{ID: ID(strings.TrimSpace(token[1]))}
+			case "pleroma:chat_update":
+				var chat Chat
+				err = json.Unmarshal([]byte(token[1]), &chat)
+				if err == nil {
+					q <- &ChatUpdateEvent{&chat}
+				}
 			}
 			if err != nil {
 				q <- &ErrorEvent{err}
@@ -86,6 +86,82 @@ data: {"content": "foo"}
 	wg.Wait()
 }
 
+func TestHandleReaderAnnouncements(t *testing.T) {
+	q := make(chan Event)
+	r := strings.NewReader(`
+event: announcement
+data: {"id": "8", "content": "<p>Hello</p>", "all_day": true}
+event: announcement.reaction
+data: {"name": "bongoCat", "count": 9, "announcement_id": "8"}
+event: announcement.delete
+data: 8
+`)
+	go func() {
+		defer close(q)
+		err := handleReader(q, r)
+		if err != nil {
+			t.Errorf("should not be fail: %v", err)
+		}
+	}()
+	var passAnnouncement, passReaction, passDelete bool
+	for e := range q {
+		switch event := e.(type) {
+		case *AnnouncementEvent:
+			passAnnouncement = true
+			if event.Announcement.Content != "<p>Hello</p>" || !event.Announcement.AllDay {
+				t.Fatalf("bad announcement: %+v", event.Announcement)
+			}
+		case *AnnouncementReactionEvent:
+			passReaction = true
+			if event.Reaction.Name != "bongoCat" || event.Reaction.Count != 9 || event.Reaction.AnnouncementID != "8" {
+				t.Fatalf("bad reaction: %+v", event.Reaction)
+			}
+		case *AnnouncementDeleteEvent:
+			passDelete = true
+			if event.ID != "8" {
+				t.Fatalf("want %q but %q", "8", event.ID)
+			}
+		case *ErrorEvent:
+			t.Fatalf("should not be fail: %v", event)
+		}
+	}
+	if !passAnnouncement || !passReaction || !passDelete {
+		t.Fatalf("have not passed through somewhere: "+
+			"announcement: %t, reaction: %t, delete: %t",
+			passAnnouncement, passReaction, passDelete)
+	}
+}
+
+func TestHandleReaderChatUpdate(t *testing.T) {
+	q := make(chan Event)
+	r := strings.NewReader(`
+event: pleroma:chat_update
+data: {"id": "1", "account": {"id": "2", "acct": "foo"}, "unread": 3, "last_message": {"id": "4", "chat_id": "1", "content": "hi"}}
+`)
+	go func() {
+		defer close(q)
+		err := handleReader(q, r)
+		if err != nil {
+			t.Errorf("should not be fail: %v", err)
+		}
+	}()
+	var passChat bool
+	for e := range q {
+		switch event := e.(type) {
+		case *ChatUpdateEvent:
+			passChat = true
+			if event.Chat.ID != "1" || event.Chat.Unread != 3 || event.Chat.LastMessage.Content != "hi" {
+				t.Fatalf("bad chat: %+v", event.Chat)
+			}
+		case *ErrorEvent:
+			t.Fatalf("should not be fail: %v", event)
+		}
+	}
+	if !passChat {
+		t.Fatal("have not passed through chat update")
+	}
+}
+
 func TestStreaming(t *testing.T) {
 	var isEnd bool
 	canErr := true
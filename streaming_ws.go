@@ -145,6 +145,26 @@ func (c *WSClient) handleWS(ctx context.Context, rawurl string, q chan Event) er
 			} else {
 				q <- &DeleteEvent{ID: ID(strings.TrimSpace(s.Payload.(string)))}
 			}
+		case "announcement":
+			var announcement Announcement
+			err = json.Unmarshal([]byte(s.Payload.(string)), &announcement)
+			if err == nil {
+				q <- &AnnouncementEvent{Announcement: &announcement}
+			}
+		case "announcement.reaction":
+			var reaction AnnouncementReaction
+			err = json.Unmarshal([]byte(s.Payload.(string)), &reaction)
+			if err == nil {
+				q <- &AnnouncementReactionEvent{Reaction: &reaction}
+			}
+		case "announcement.delete":
+			q <- &AnnouncementDeleteEvent{ID: ID(strings.TrimSpace(s.Payload.(string)))}
+		case "pleroma:chat_update":
+			var chat Chat
+			err = json.Unmarshal([]byte(s.Payload.(string)), &chat)
+			if err == nil {
+				q <- &ChatUpdateEvent{Chat: &chat}
+			}
 		}
 		if err != nil {
 			q <- &ErrorEvent{err}
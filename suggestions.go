@@ -0,0 +1,67 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Suggestion holds an account suggested to follow, and why.
+type Suggestion struct {
+	// Source is one of "staff", "past_interactions" or "global".
+	//
+	// Deprecated: Newer versions of Mastodon use Sources.
+	Source  string   `json:"source"`
+	Sources []string `json:"sources"`
+	Account *Account `json:"account"`
+}
+
+// GetSuggestions returns accounts the user is suggested to follow.
+func (c *Client) GetSuggestions(ctx context.Context, limit int) ([]*Suggestion, error) {
+	params := url.Values{}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	var suggestions []*Suggestion
+	err := c.doAPI(ctx, http.MethodGet, "/api/v2/suggestions", params, &suggestions, nil)
+	if err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
+
+// RemoveSuggestion removes an account from the follow suggestions.
+func (c *Client) RemoveSuggestion(ctx context.Context, id ID) error {
+	return c.doAPI(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/suggestions/%s", url.PathEscape(id)), nil, nil, nil)
+}
+
+// DirectoryOpts represent the options that may be supplied to GetDirectory.
+type DirectoryOpts struct {
+	Offset int
+	Limit  int
+	Order  string // "active" (the default) or "new"
+	Local  bool   // Only include accounts from this instance.
+}
+
+func (d DirectoryOpts) params() url.Values {
+	params := offsetParams(d.Offset, d.Limit)
+
+	stradd, badd := addParamFuncs(&params)
+	stradd("order", d.Order)
+	badd("local", d.Local)
+
+	return params
+}
+
+// GetDirectory returns accounts that have opted in to the profile directory.
+func (c *Client) GetDirectory(ctx context.Context, opts DirectoryOpts) ([]*Account, error) {
+	var accounts []*Account
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/directory", opts.params(), &accounts, nil)
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
@@ -0,0 +1,112 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetSuggestions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/suggestions" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		if r.FormValue("limit") != "2" {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, `[{"source": "staff", "sources": ["featured"], "account": {"id": "1", "username": "foo"}}, {"source": "past_interactions", "sources": ["most_interactions"], "account": {"id": "2", "username": "bar"}}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	_, err := client.GetSuggestions(context.Background(), 0)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	suggestions, err := client.GetSuggestions(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("result should be two: %d", len(suggestions))
+	}
+	if suggestions[0].Source != "staff" {
+		t.Fatalf("want %q but %q", "staff", suggestions[0].Source)
+	}
+	if suggestions[1].Sources[0] != "most_interactions" {
+		t.Fatalf("want %q but %q", "most_interactions", suggestions[1].Sources[0])
+	}
+	if suggestions[1].Account.Username != "bar" {
+		t.Fatalf("want %q but %q", "bar", suggestions[1].Account.Username)
+	}
+}
+
+func TestRemoveSuggestion(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/v1/suggestions/1234" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	err := client.RemoveSuggestion(context.Background(), "123")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	err = client.RemoveSuggestion(context.Background(), "1234")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+}
+
+func TestGetDirectory(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/directory" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		if r.FormValue("order") != "new" || r.FormValue("local") != "true" || r.FormValue("offset") != "40" || r.FormValue("limit") != "2" {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, `[{"username": "foo"}, {"username": "bar"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	_, err := client.GetDirectory(context.Background(), DirectoryOpts{})
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	accounts, err := client.GetDirectory(context.Background(), DirectoryOpts{
+		Offset: 40,
+		Limit:  2,
+		Order:  "new",
+		Local:  true,
+	})
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(accounts) != 2 {
+		t.Fatalf("result should be two: %d", len(accounts))
+	}
+	if accounts[1].Username != "bar" {
+		t.Fatalf("want %q but %q", "bar", accounts[1].Username)
+	}
+}
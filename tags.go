@@ -0,0 +1,111 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// FeaturedTag holds information for a tag featured on a user's profile.
+type FeaturedTag struct {
+	ID            ID     `json:"id"`
+	Name          string `json:"name"`
+	URL           string `json:"url"`
+	StatusesCount Sint   `json:"statuses_count"`
+	LastStatusAt  string `json:"last_status_at"` // A date, such as "2022-08-29"
+}
+
+func tagPath(format, name string) string {
+	return fmt.Sprintf(format, strings.TrimPrefix(name, "#"))
+}
+
+// GetTag returns a hashtag, including whether the user follows it.
+func (c *Client) GetTag(ctx context.Context, name string) (*Tag, error) {
+	var tag Tag
+	err := c.doAPI(ctx, http.MethodGet, tagPath("/api/v1/tags/%s", name), nil, &tag, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// FollowTag follows a hashtag, its statuses will appear in the home timeline.
+func (c *Client) FollowTag(ctx context.Context, name string) (*Tag, error) {
+	var tag Tag
+	err := c.doAPI(ctx, http.MethodPost, tagPath("/api/v1/tags/%s/follow", name), nil, &tag, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// UnfollowTag unfollows a hashtag.
+func (c *Client) UnfollowTag(ctx context.Context, name string) (*Tag, error) {
+	var tag Tag
+	err := c.doAPI(ctx, http.MethodPost, tagPath("/api/v1/tags/%s/unfollow", name), nil, &tag, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// GetFollowedTags returns the hashtags followed by the current user.
+func (c *Client) GetFollowedTags(ctx context.Context, pg *Pagination) ([]*Tag, error) {
+	var tags []*Tag
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/followed_tags", nil, &tags, pg)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetFeaturedTags returns the hashtags featured on the current user's profile.
+func (c *Client) GetFeaturedTags(ctx context.Context) ([]*FeaturedTag, error) {
+	var tags []*FeaturedTag
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/featured_tags", nil, &tags, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// FeatureTag features a hashtag on the current user's profile.
+func (c *Client) FeatureTag(ctx context.Context, name string) (*FeaturedTag, error) {
+	params := url.Values{}
+	params.Set("name", strings.TrimPrefix(name, "#"))
+
+	var tag FeaturedTag
+	err := c.doAPI(ctx, http.MethodPost, "/api/v1/featured_tags", params, &tag, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// UnfeatureTag stops featuring a hashtag, id is FeaturedTag.ID.
+func (c *Client) UnfeatureTag(ctx context.Context, id ID) error {
+	return c.doAPI(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/featured_tags/%s", url.PathEscape(id)), nil, nil, nil)
+}
+
+// GetFeaturedTagSuggestions returns the hashtags the current user uses most,
+// which aren't featured yet.
+func (c *Client) GetFeaturedTagSuggestions(ctx context.Context) ([]*Tag, error) {
+	var tags []*Tag
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/featured_tags/suggestions", nil, &tags, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetAccountFeaturedTags returns the hashtags featured on an account's profile.
+func (c *Client) GetAccountFeaturedTags(ctx context.Context, id ID) ([]*FeaturedTag, error) {
+	var tags []*FeaturedTag
+	err := c.doAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v1/accounts/%s/featured_tags", url.PathEscape(id)), nil, &tags, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
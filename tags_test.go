@@ -0,0 +1,185 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetTag(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/tags/わろす" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `{"id": "1", "name": "わろす", "url": "https://example.com/tags/わろす", "history": [], "following": true}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	_, err := client.GetTag(context.Background(), "foo")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	tag, err := client.GetTag(context.Background(), "#わろす")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if tag.Name != "わろす" {
+		t.Fatalf("want %q but %q", "わろす", tag.Name)
+	}
+	if !tag.Following {
+		t.Fatal("want following")
+	}
+}
+
+func TestFollowTag(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/tags/spiders/follow":
+			fmt.Fprintln(w, `{"name": "spiders", "following": true}`)
+		case "/api/v1/tags/spiders/unfollow":
+			fmt.Fprintln(w, `{"name": "spiders", "following": false}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	tag, err := client.FollowTag(context.Background(), "spiders")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if !tag.Following {
+		t.Fatal("want following")
+	}
+	tag, err = client.UnfollowTag(context.Background(), "spiders")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if tag.Following {
+		t.Fatal("want not following")
+	}
+}
+
+func TestGetFollowedTags(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/followed_tags" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Link", `<http://example.com/api/v1/followed_tags?max_id=42>; rel="next"`)
+		fmt.Fprintln(w, `[{"name": "foo", "following": true}, {"name": "bar", "following": true}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	var pg Pagination
+	tags, err := client.GetFollowedTags(context.Background(), &pg)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(tags) != 2 {
+		t.Fatalf("result should be two: %d", len(tags))
+	}
+	if tags[1].Name != "bar" {
+		t.Fatalf("want %q but %q", "bar", tags[1].Name)
+	}
+	if pg.MaxID != "42" {
+		t.Fatalf("want %q but %q", "42", pg.MaxID)
+	}
+}
+
+func TestFeaturedTags(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/featured_tags":
+			fmt.Fprintln(w, `[{"id": "627", "name": "nowplaying", "url": "https://example.com/@foo/tagged/nowplaying", "statuses_count": "70", "last_status_at": "2022-08-29"}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/accounts/1/featured_tags":
+			fmt.Fprintln(w, `[{"id": "628", "name": "spiders", "statuses_count": 3}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/featured_tags/suggestions":
+			fmt.Fprintln(w, `[{"name": "caturday"}]`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/featured_tags":
+			fmt.Fprintf(w, `{"id": "629", "name": %q, "statuses_count": 0}`, r.PostFormValue("name"))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/featured_tags/629":
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		Server:       ts.URL,
+		ClientID:     "foo",
+		ClientSecret: "bar",
+		AccessToken:  "zoo",
+	})
+	tags, err := client.GetFeaturedTags(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(tags) != 1 {
+		t.Fatalf("result should be one: %d", len(tags))
+	}
+	if tags[0].StatusesCount != 70 {
+		t.Fatalf("want %v but %v", 70, tags[0].StatusesCount)
+	}
+	if tags[0].LastStatusAt != "2022-08-29" {
+		t.Fatalf("want %q but %q", "2022-08-29", tags[0].LastStatusAt)
+	}
+
+	tags, err = client.GetAccountFeaturedTags(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if tags[0].StatusesCount != 3 {
+		t.Fatalf("want %v but %v", 3, tags[0].StatusesCount)
+	}
+
+	suggestions, err := client.GetFeaturedTagSuggestions(context.Background())
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if suggestions[0].Name != "caturday" {
+		t.Fatalf("want %q but %q", "caturday", suggestions[0].Name)
+	}
+
+	tag, err := client.FeatureTag(context.Background(), "#cats")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if tag.Name != "cats" {
+		t.Fatalf("want %q but %q", "cats", tag.Name)
+	}
+
+	err = client.UnfeatureTag(context.Background(), "628")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	err = client.UnfeatureTag(context.Background(), tag.ID)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package masta
+
+import (
+	"context"
+	"net/http"
+)
+
+// TrendsLink holds information for a link that is trending.
+type TrendsLink struct {
+	Card
+	History []History `json:"history"`
+}
+
+// GetTrendingTags returns tags that are being used more frequently within
+// the past week. Results are skipped by offset, and zero values use the
+// server's defaults.
+func (c *Client) GetTrendingTags(ctx context.Context, offset, limit int) ([]*Tag, error) {
+	var tags []*Tag
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/trends/tags", offsetParams(offset, limit), &tags, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetTrendingStatuses returns statuses that have been interacted with more
+// than others.
+func (c *Client) GetTrendingStatuses(ctx context.Context, offset, limit int) ([]*Status, error) {
+	var statuses []*Status
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/trends/statuses", offsetParams(offset, limit), &statuses, nil)
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// GetTrendingLinks returns links that have been shared more than others.
+func (c *Client) GetTrendingLinks(ctx context.Context, offset, limit int) ([]*TrendsLink, error) {
+	var links []*TrendsLink
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/trends/links", offsetParams(offset, limit), &links, nil)
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
@@ -0,0 +1,95 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetTrendingTags(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/trends/tags" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		if r.FormValue("offset") != "10" || r.FormValue("limit") != "2" {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, `[{"name": "caturday", "url": "https://example.com/tags/caturday", "history": [{"day": "1574553600", "uses": "200", "accounts": "31"}]}, {"name": "spiders", "url": "https://example.com/tags/spiders", "history": []}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	_, err := client.GetTrendingTags(context.Background(), 0, 0)
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	tags, err := client.GetTrendingTags(context.Background(), 10, 2)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(tags) != 2 {
+		t.Fatalf("result should be two: %d", len(tags))
+	}
+	if tags[0].Name != "caturday" {
+		t.Fatalf("want %q but %q", "caturday", tags[0].Name)
+	}
+	if tags[0].History[0].Uses != "200" {
+		t.Fatalf("want %q but %q", "200", tags[0].History[0].Uses)
+	}
+}
+
+func TestGetTrendingStatuses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/trends/statuses" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `[{"id": "1", "content": "foo"}, {"id": "2", "content": "bar"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	statuses, err := client.GetTrendingStatuses(context.Background(), 0, 0)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("result should be two: %d", len(statuses))
+	}
+	if statuses[1].Content != "bar" {
+		t.Fatalf("want %q but %q", "bar", statuses[1].Content)
+	}
+}
+
+func TestGetTrendingLinks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/trends/links" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `[{"url": "https://example.com/news", "title": "News", "type": "link", "provider_name": "Example", "history": [{"day": "1574553600", "uses": "9", "accounts": "8"}]}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{Server: ts.URL})
+	links, err := client.GetTrendingLinks(context.Background(), 0, 0)
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if len(links) != 1 {
+		t.Fatalf("result should be one: %d", len(links))
+	}
+	if links[0].URL != "https://example.com/news" {
+		t.Fatalf("want %q but %q", "https://example.com/news", links[0].URL)
+	}
+	if links[0].ProviderName != "Example" {
+		t.Fatalf("want %q but %q", "Example", links[0].ProviderName)
+	}
+	if len(links[0].History) != 1 || links[0].History[0].Accounts != "8" {
+		t.Fatalf("want one history entry but %v", links[0].History)
+	}
+}
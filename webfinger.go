@@ -0,0 +1,92 @@
+package masta
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// WebFingerResource is the result of a WebFinger lookup. ActorURI, ProfileURL
+// and SubscribeTemplate are picked out of Links for convenience.
+type WebFingerResource struct {
+	Subject string          `json:"subject"`
+	Aliases []string        `json:"aliases"`
+	Links   []WebFingerLink `json:"links"`
+
+	ActorURI          string `json:"-"`
+	ProfileURL        string `json:"-"`
+	SubscribeTemplate string `json:"-"`
+}
+
+// WebFingerLink holds a link in a WebFinger resource.
+type WebFingerLink struct {
+	Rel      string `json:"rel"`
+	Type     string `json:"type"`
+	Href     string `json:"href"`
+	Template string `json:"template"`
+}
+
+// Acct returns the canonical user@host handle of the resource.
+func (r *WebFingerResource) Acct() string {
+	return strings.TrimPrefix(r.Subject, "acct:")
+}
+
+// SplitAcct splits a handle such as "user@host", "@user@host" or
+// "acct:user@host" into its username and host.
+func SplitAcct(acct string) (username, host string, err error) {
+	acct = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(acct), "acct:"), "@")
+	i := strings.LastIndex(acct, "@")
+	if i <= 0 || i == len(acct)-1 {
+		return "", "", errors.New("acct must be in the form of user@host")
+	}
+	return acct[:i], acct[i+1:], nil
+}
+
+// WebFinger resolves a user@host handle. The lookup is sent to server,
+// which is a domain name or a base URL as for GetNodeInfo, or to the host
+// of the handle if server is empty. No authentication is required.
+func WebFinger(ctx context.Context, server, acct string) (*WebFingerResource, error) {
+	return webFinger(ctx, http.DefaultClient, "", server, acct)
+}
+
+func webFinger(ctx context.Context, client *http.Client, userAgent, server, acct string) (*WebFingerResource, error) {
+	username, host, err := SplitAcct(acct)
+	if err != nil {
+		return nil, err
+	}
+	if server == "" {
+		server = host
+	}
+
+	u, err := hostURL(server)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "/.well-known/webfinger")
+	u.RawQuery = url.Values{"resource": {"acct:" + username + "@" + host}}.Encode()
+
+	var res WebFingerResource
+	if err := getJSON(ctx, client, userAgent, u.String(), &res); err != nil {
+		return nil, err
+	}
+
+	for _, link := range res.Links {
+		switch link.Rel {
+		case "self":
+			if res.ActorURI == "" || link.Type == "application/activity+json" {
+				res.ActorURI = link.Href
+			}
+		case "http://webfinger.net/rel/profile-page":
+			if res.ProfileURL == "" || link.Type == "text/html" {
+				res.ProfileURL = link.Href
+			}
+		case "http://ostatus.org/schema/1.0/subscribe":
+			res.SubscribeTemplate = link.Template
+		}
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,82 @@
+package masta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebFinger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/webfinger" || r.FormValue("resource") != "acct:Foo@example.com" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, `{"subject": "acct:foo@example.com", "aliases": ["https://example.com/@foo"], "links": [{"rel": "http://webfinger.net/rel/profile-page", "type": "text/html", "href": "https://example.com/@foo"}, {"rel": "self", "type": "application/activity+json", "href": "https://example.com/users/foo"}, {"rel": "http://ostatus.org/schema/1.0/subscribe", "template": "https://example.com/authorize_interaction?uri={uri}"}]}`)
+	}))
+	defer ts.Close()
+
+	_, err := WebFinger(context.Background(), ts.URL, "bar@example.com")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	res, err := WebFinger(context.Background(), ts.URL, "@Foo@example.com")
+	if err != nil {
+		t.Fatalf("should not be fail: %v", err)
+	}
+	if res.Acct() != "foo@example.com" {
+		t.Fatalf("want %q but %q", "foo@example.com", res.Acct())
+	}
+	if res.ActorURI != "https://example.com/users/foo" {
+		t.Fatalf("want %q but %q", "https://example.com/users/foo", res.ActorURI)
+	}
+	if res.ProfileURL != "https://example.com/@foo" {
+		t.Fatalf("want %q but %q", "https://example.com/@foo", res.ProfileURL)
+	}
+	if res.SubscribeTemplate != "https://example.com/authorize_interaction?uri={uri}" {
+		t.Fatalf("want %q but %q", "https://example.com/authorize_interaction?uri={uri}", res.SubscribeTemplate)
+	}
+}
+
+func TestSplitAcct(t *testing.T) {
+	tests := []struct {
+		acct, username, host string
+		fail                 bool
+	}{
+		{"foo@example.com", "foo", "example.com", false},
+		{"@foo@example.com", "foo", "example.com", false},
+		{"acct:foo@example.com", "foo", "example.com", false},
+		{"foo", "", "", true},
+		{"@foo", "", "", true},
+		{"foo@", "", "", true},
+	}
+	for _, test := range tests {
+		username, host, err := SplitAcct(test.acct)
+		if test.fail {
+			if err == nil {
+				t.Errorf("%q should be fail", test.acct)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q should not be fail: %v", test.acct, err)
+			continue
+		}
+		if username != test.username || host != test.host {
+			t.Errorf("want %q, %q but %q, %q", test.username, test.host, username, host)
+		}
+	}
+}
+
+func TestWebFingerInvalid(t *testing.T) {
+	_, err := WebFinger(context.Background(), "", "foo")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+	_, err = WebFinger(context.Background(), "https://", "foo@example.com")
+	if err == nil {
+		t.Fatalf("should be fail: %v", err)
+	}
+}